@@ -0,0 +1,75 @@
+// Command loccheck reports localization keys which are missing, orphaned or duplicated,
+// comparing every language against a base language and against the entities defined in the game and mods.
+//
+// Usage:
+//
+//	loccheck [-game dir] [-mod dir]... [-base english] [-languages french,german]
+//
+// It exits with status 1 if any problem is found, so it can be used in pre-release checks.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"vic3-data-reader/internal/data/localization"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/mods"
+)
+
+func main() {
+	gameDir := flag.String("game", "", "the game install directory, defaulting to VIC3_DIR")
+	var modDirs []string
+	flag.Func("mod", "a mod directory to load after the game; may be repeated, in load order", func(dir string) error {
+		modDirs = append(modDirs, dir)
+		return nil
+	})
+	base := flag.String("base", localization.English, "the language every other language is compared against")
+	languages := flag.String("languages", strings.Join(localization.AllLanguages, ","), "comma separated languages to check")
+	flag.Parse()
+
+	root, err := openRoot(*gameDir, modDirs)
+	if err != nil {
+		fail(err)
+	}
+	report, err := localization.CheckFrom(root, *base, strings.Split(*languages, ","), localization.DefaultExpectations)
+	if err != nil {
+		fail(err)
+	}
+	if err := report.Write(os.Stdout); err != nil {
+		fail(err)
+	}
+	if !report.OK() {
+		os.Exit(1)
+	}
+}
+
+// openRoot opens the game install, stacked with the mods if there are any
+func openRoot(gameDir string, modDirs []string) (dirs.Root, error) {
+	g, err := dirs.DefaultGameInstall()
+	if gameDir != "" {
+		g, err = dirs.OpenGameInstall(gameDir), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(modDirs) == 0 {
+		return g, nil
+	}
+
+	var loaded []mods.Mod
+	for _, dir := range modDirs {
+		m, err := mods.OpenDir(dir)
+		if err != nil {
+			return nil, err
+		}
+		loaded = append(loaded, m)
+	}
+	return mods.NewStack(g, loaded...), nil
+}
+
+func fail(err error) {
+	_, _ = fmt.Fprintln(os.Stderr, "loccheck:", err)
+	os.Exit(2)
+}
@@ -0,0 +1,110 @@
+package buildinggroups
+
+import (
+	"fmt"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+// BuildingGroup is a single entry in common/building_groups.
+// Groups form a tree through parent_group.
+type BuildingGroup struct {
+	Key                         string     `pdx:",key"`
+	ParentGroup                 entity.Ref `pdx:"parent_group"`
+	Category                    string     `pdx:"category"`
+	AlwaysPossible              bool       `pdx:"always_possible"`
+	EconomyOfScale              bool       `pdx:"economy_of_scale"`
+	IsSubsistence               bool       `pdx:"is_subsistence"`
+	DefaultBuilding             string     `pdx:"default_building"`
+	Lens                        string     `pdx:"lens"`
+	Urbanization                float64    `pdx:"urbanization"`
+	InfrastructureUsagePerLevel float64    `pdx:"infrastructure_usage_per_level"`
+}
+
+// BuildingGroups are kept in the order the game defines them
+type BuildingGroups = entity.Collection[BuildingGroup]
+
+// Load loads every group from the game's building groups directory
+func Load() (*BuildingGroups, error) {
+	return entity.LoadDir[BuildingGroup](dirs.BuildingGroups)
+}
+
+// LoadFrom loads every group from the building groups directory of the root
+func LoadFrom(g dirs.Root) (*BuildingGroups, error) {
+	return entity.LoadInstall[BuildingGroup](g, dirs.BuildingGroups)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*BuildingGroups, error) {
+	return entity.LoadFiles[BuildingGroup](srcs)
+}
+
+// Ancestors returns the group followed by each of its parents, ending with the root group
+func Ancestors(groups *BuildingGroups, key string) ([]BuildingGroup, error) {
+	var path []BuildingGroup
+	seen := map[string]bool{}
+	for key != "" {
+		if seen[key] {
+			return path, fmt.Errorf("building group %q has a cyclic parent_group", key)
+		}
+		seen[key] = true
+
+		group, ok := groups.Get(key)
+		if !ok {
+			return path, fmt.Errorf("building group %q is not defined", key)
+		}
+		path = append(path, group)
+		key = group.ParentGroup.Key
+	}
+	return path, nil
+}
+
+// Root returns the top level group of the group, e.g. bg_manufacturing for bg_light_industry
+func Root(groups *BuildingGroups, key string) (BuildingGroup, error) {
+	path, err := Ancestors(groups, key)
+	if err != nil {
+		return BuildingGroup{}, err
+	}
+	return path[len(path)-1], nil
+}
+
+// Children returns the groups whose parent_group is the group, in definition order
+func Children(groups *BuildingGroups, key string) []BuildingGroup {
+	var children []BuildingGroup
+	for _, g := range groups.Values() {
+		if g.ParentGroup.Key == key {
+			children = append(children, g)
+		}
+	}
+	return children
+}
+
+// Descendants returns every group below the group, depth first in definition order
+func Descendants(groups *BuildingGroups, key string) []BuildingGroup {
+	var descendants []BuildingGroup
+	seen := map[string]bool{key: true}
+	var walk func(string)
+	walk = func(parent string) {
+		for _, child := range Children(groups, parent) {
+			if seen[child.Key] {
+				continue
+			}
+			seen[child.Key] = true
+			descendants = append(descendants, child)
+			walk(child.Key)
+		}
+	}
+	walk(key)
+	return descendants
+}
+
+// Category returns the category of the group, inherited from the nearest ancestor which sets one
+func Category(groups *BuildingGroups, key string) (string, error) {
+	path, err := Ancestors(groups, key)
+	for _, g := range path {
+		if g.Category != "" {
+			return g.Category, nil
+		}
+	}
+	return "", err
+}
@@ -0,0 +1,117 @@
+package buildinggroups
+
+import (
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	SmokeSample files.DataFile = "testdata/00_building_groups.txt"
+	Cycle       files.DataFile = "testdata/cycle.txt"
+)
+
+func load(t *testing.T, df files.DataFile) *BuildingGroups {
+	groups, err := LoadFiles([]files.DataFile{df})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return groups
+}
+
+func keys(groups []BuildingGroup) []string {
+	var ks []string
+	for _, g := range groups {
+		ks = append(ks, g.Key)
+	}
+	return ks
+}
+
+func checkKeys(t *testing.T, expected []string, actual []BuildingGroup) {
+	ks := keys(actual)
+	if len(ks) != len(expected) {
+		t.Fatalf("expected: %v, actual: %v", expected, ks)
+	}
+	for i := range expected {
+		if ks[i] != expected[i] {
+			t.Errorf("expected: %v, actual: %v", expected, ks)
+			return
+		}
+	}
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	groups := load(t, SmokeSample)
+
+	manufacturing, _ := groups.Get("bg_manufacturing")
+	if manufacturing.Category != "development" || !manufacturing.AlwaysPossible || !manufacturing.EconomyOfScale {
+		t.Errorf("unexpected bg_manufacturing: %+v", manufacturing)
+	}
+	subsistence, _ := groups.Get("bg_subsistence_agriculture")
+	if !subsistence.IsSubsistence || subsistence.EconomyOfScale {
+		t.Errorf("unexpected bg_subsistence_agriculture: %+v", subsistence)
+	}
+	if subsistence.ParentGroup.Key != "bg_agriculture" {
+		t.Errorf("expected: %s, actual: %s", "bg_agriculture", subsistence.ParentGroup)
+	}
+}
+
+func TestAncestors(t *testing.T) {
+	path, err := Ancestors(load(t, SmokeSample), "bg_arms_industry")
+	if err != nil {
+		t.Fatalf("Ancestors returned unexpected error: %v", err)
+	}
+	checkKeys(t, []string{"bg_arms_industry", "bg_heavy_industry", "bg_manufacturing"}, path)
+}
+
+func TestRoot(t *testing.T) {
+	groups := load(t, SmokeSample)
+	tests := map[string]string{
+		"bg_arms_industry":           "bg_manufacturing",
+		"bg_light_industry":          "bg_manufacturing",
+		"bg_manufacturing":           "bg_manufacturing",
+		"bg_subsistence_agriculture": "bg_agriculture",
+	}
+	for key, expected := range tests {
+		root, err := Root(groups, key)
+		if err != nil {
+			t.Errorf("Root returned unexpected error: %v", err)
+		} else if root.Key != expected {
+			t.Errorf("%s: expected: %s, actual: %s", key, expected, root.Key)
+		}
+	}
+}
+
+func TestDescendants(t *testing.T) {
+	groups := load(t, SmokeSample)
+	checkKeys(t, []string{"bg_light_industry", "bg_heavy_industry", "bg_arms_industry"}, Descendants(groups, "bg_manufacturing"))
+	checkKeys(t, nil, Descendants(groups, "bg_arms_industry"))
+}
+
+func TestCategory_isInherited(t *testing.T) {
+	category, err := Category(load(t, SmokeSample), "bg_arms_industry")
+	if err != nil {
+		t.Fatalf("Category returned unexpected error: %v", err)
+	}
+	if category != "development" {
+		t.Errorf("expected: %s, actual: %s", "development", category)
+	}
+}
+
+func TestAncestors_cycleReturnsError(t *testing.T) {
+	_, err := Ancestors(load(t, Cycle), "bg_a")
+	if err == nil {
+		t.Errorf("Ancestors did not return an error for a cycle")
+	}
+}
+
+func TestAncestors_missingParentReturnsError(t *testing.T) {
+	path, err := Ancestors(load(t, Cycle), "bg_orphan")
+	if err == nil {
+		t.Errorf("Ancestors did not return an error for a missing parent")
+	}
+	checkKeys(t, []string{"bg_orphan"}, path)
+}
+
+func TestDescendants_cycleTerminates(t *testing.T) {
+	checkKeys(t, []string{"bg_b"}, Descendants(load(t, Cycle), "bg_a"))
+}
@@ -0,0 +1,75 @@
+package buildings
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Building is a single entry in common/buildings.
+// Trigger blocks are kept as raw syntax trees, since they are game script rather than data.
+type Building struct {
+	Key                    string       `pdx:",key"`
+	BuildingGroup          entity.Ref   `pdx:"building_group"`
+	Texture                string       `pdx:"texture"`
+	Background             string       `pdx:"background"`
+	CityType               string       `pdx:"city_type"`
+	Locator                string       `pdx:"locator"`
+	LevelsPerMesh          int          `pdx:"levels_per_mesh"`
+	RequiredConstruction   string       `pdx:"required_construction"`
+	UnlockingTechnologies  []entity.Ref `pdx:"unlocking_technologies"`
+	ProductionMethodGroups []entity.Ref `pdx:"production_method_groups"`
+	Buildable              *bool        `pdx:"buildable"`
+	Expandable             *bool        `pdx:"expandable"`
+	Downsizeable           *bool        `pdx:"downsizeable"`
+	Unique                 bool         `pdx:"unique"`
+	Port                   bool         `pdx:"port"`
+	HasMaxLevel            bool         `pdx:"has_max_level"`
+
+	Potential *parser.Block `pdx:"potential"`
+	Possible  *parser.Block `pdx:"possible"`
+}
+
+// IsBuildable is true unless the building sets `buildable = no`
+func (b Building) IsBuildable() bool {
+	return b.Buildable == nil || *b.Buildable
+}
+
+// IsExpandable is true unless the building sets `expandable = no`
+func (b Building) IsExpandable() bool {
+	return b.Expandable == nil || *b.Expandable
+}
+
+// IsDownsizeable is true unless the building sets `downsizeable = no`
+func (b Building) IsDownsizeable() bool {
+	return b.Downsizeable == nil || *b.Downsizeable
+}
+
+// Buildings are kept in the order the game defines them
+type Buildings = entity.Collection[Building]
+
+// Load loads and merges every file from the game's buildings directory
+func Load() (*Buildings, error) {
+	return entity.LoadDir[Building](dirs.Buildings)
+}
+
+// LoadFrom loads and merges every file from the buildings directory of the root
+func LoadFrom(g dirs.Root) (*Buildings, error) {
+	return entity.LoadInstall[Building](g, dirs.Buildings)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*Buildings, error) {
+	return entity.LoadFiles[Building](srcs)
+}
+
+// ByGroup returns the buildings in the building group, in definition order
+func ByGroup(buildings *Buildings, group string) []Building {
+	var matches []Building
+	for _, b := range buildings.Values() {
+		if b.BuildingGroup.Key == group {
+			matches = append(matches, b)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,110 @@
+package buildings
+
+import (
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	Industry          files.DataFile = "testdata/01_industry.txt"
+	Government        files.DataFile = "testdata/02_government.txt"
+	ExpectedBuildings                = 4
+)
+
+func load(t *testing.T) *Buildings {
+	buildings, err := LoadFiles([]files.DataFile{Industry, Government})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return buildings
+}
+
+func TestLoadFiles_mergesFiles(t *testing.T) {
+	buildings := load(t)
+	if buildings.Len() != ExpectedBuildings {
+		t.Fatalf("expected %d buildings, actual: %d", ExpectedBuildings, buildings.Len())
+	}
+
+	expected := []string{"building_food_industry", "building_arms_industry", "building_government_administration", "building_port"}
+	for i, key := range buildings.Keys() {
+		if key != expected[i] {
+			t.Errorf("expected: %s, actual: %s", expected[i], key)
+		}
+	}
+
+	port, _ := buildings.Entry("building_port")
+	if port.Source.File != string(Government) {
+		t.Errorf("expected: %s, actual: %s", Government, port.Source.File)
+	}
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	food, ok := load(t).Get("building_food_industry")
+	if !ok {
+		t.Fatalf("missing building %q", "building_food_industry")
+	}
+
+	if food.BuildingGroup.Key != "bg_light_industry" {
+		t.Errorf("expected: %s, actual: %s", "bg_light_industry", food.BuildingGroup)
+	}
+	if food.CityType != "city" {
+		t.Errorf("expected: %s, actual: %s", "city", food.CityType)
+	}
+	if food.LevelsPerMesh != 5 {
+		t.Errorf("expected: %d, actual: %d", 5, food.LevelsPerMesh)
+	}
+	if food.RequiredConstruction != "construction_cost_medium" {
+		t.Errorf("expected: %s, actual: %s", "construction_cost_medium", food.RequiredConstruction)
+	}
+	if len(food.UnlockingTechnologies) != 1 || food.UnlockingTechnologies[0].Key != "manufacturies" {
+		t.Errorf("unexpected unlocking_technologies: %v", food.UnlockingTechnologies)
+	}
+	if len(food.ProductionMethodGroups) != 5 || food.ProductionMethodGroups[1].Key != "pmg_canning" {
+		t.Errorf("unexpected production_method_groups: %v", food.ProductionMethodGroups)
+	}
+}
+
+func TestLoadFiles_numericConstruction(t *testing.T) {
+	port, _ := load(t).Get("building_port")
+	if port.RequiredConstruction != "100" {
+		t.Errorf("expected: %s, actual: %s", "100", port.RequiredConstruction)
+	}
+}
+
+func TestLoadFiles_defaults(t *testing.T) {
+	buildings := load(t)
+
+	food, _ := buildings.Get("building_food_industry")
+	if !food.IsBuildable() || !food.IsExpandable() || !food.IsDownsizeable() {
+		t.Errorf("buildings should be buildable, expandable and downsizeable by default")
+	}
+
+	gov, _ := buildings.Get("building_government_administration")
+	if gov.Unique {
+		t.Errorf("expected unique = no")
+	}
+}
+
+func TestLoadFiles_triggersAreRaw(t *testing.T) {
+	buildings := load(t)
+
+	port, _ := buildings.Get("building_port")
+	if port.Potential == nil || port.Possible == nil {
+		t.Fatalf("expected potential and possible blocks")
+	}
+	if len(port.Possible.Fields()) != 2 {
+		t.Errorf("expected %d possible triggers, actual: %d", 2, len(port.Possible.Fields()))
+	}
+
+	food, _ := buildings.Get("building_food_industry")
+	if food.Possible != nil {
+		t.Errorf("expected no possible block")
+	}
+}
+
+func TestByGroup(t *testing.T) {
+	heavy := ByGroup(load(t), "bg_heavy_industry")
+	if len(heavy) != 1 || heavy[0].Key != "building_arms_industry" {
+		t.Errorf("unexpected buildings in bg_heavy_industry: %v", heavy)
+	}
+}
@@ -0,0 +1,268 @@
+package defines
+
+import (
+	"fmt"
+	"strconv"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+type Kind int
+
+const (
+	Int Kind = iota
+	Float
+	String
+	List
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Int:
+		return "int"
+	case Float:
+		return "float"
+	case String:
+		return "string"
+	case List:
+		return "list"
+	default:
+		return "unknown"
+	}
+}
+
+// Define is a single value within a namespace of common/defines, e.g. `NEconomy = { KEY = 0.5 }`
+type Define struct {
+	Namespace string
+	Key       string
+	Value     parser.Value
+	Source    entity.Source
+}
+
+// Kind is List for blocks, Int or Float for numbers, and String for everything else
+func (d Define) Kind() Kind {
+	switch v := d.Value.(type) {
+	case *parser.Block:
+		return List
+	case *parser.Scalar:
+		if !v.IsNumber() {
+			return String
+		}
+		if _, err := strconv.Atoi(v.Text()); err == nil {
+			return Int
+		}
+		return Float
+	}
+	return String
+}
+
+func (d Define) Float() (float64, error) {
+	s, ok := d.Value.(*parser.Scalar)
+	if !ok || !s.IsNumber() {
+		return 0, d.errorf("expected a number")
+	}
+	return strconv.ParseFloat(s.Text(), 64)
+}
+
+func (d Define) Int() (int, error) {
+	if d.Kind() != Int {
+		return 0, d.errorf("expected an int")
+	}
+	return strconv.Atoi(d.Value.(*parser.Scalar).Text())
+}
+
+// Text is the define's value as written, without quotes
+func (d Define) Text() (string, error) {
+	s, ok := d.Value.(*parser.Scalar)
+	if !ok {
+		return "", d.errorf("expected a single value")
+	}
+	return s.Text(), nil
+}
+
+// List returns the text of each item of a list such as `{ 1 2 3 }`
+func (d Define) List() ([]string, error) {
+	blk, ok := d.Value.(*parser.Block)
+	if !ok || len(blk.Fields()) > 0 || len(blk.Blocks()) > 0 {
+		return nil, d.errorf("expected a list")
+	}
+	var items []string
+	for _, s := range blk.Scalars() {
+		items = append(items, s.Text())
+	}
+	return items, nil
+}
+
+// Floats returns each item of a list of numbers
+func (d Define) Floats() ([]float64, error) {
+	items, err := d.List()
+	if err != nil {
+		return nil, err
+	}
+	floats := make([]float64, len(items))
+	for i, item := range items {
+		if floats[i], err = strconv.ParseFloat(item, 64); err != nil {
+			return nil, d.errorf("expected a list of numbers")
+		}
+	}
+	return floats, nil
+}
+
+func (d Define) errorf(format string, args ...any) error {
+	return fmt.Errorf("%s: %s.%s: %s", d.Source, d.Namespace, d.Key, fmt.Sprintf(format, args...))
+}
+
+// Override is a define which replaced one from an earlier file, or from earlier in the same file
+type Override struct {
+	Previous, Current Define
+}
+
+// Defines are every define merged across files, where later files override earlier ones
+type Defines struct {
+	namespaces []string
+	keys       map[string][]string
+	values     map[string]map[string]Define
+	overrides  []Override
+}
+
+func newDefines() *Defines {
+	return &Defines{keys: map[string][]string{}, values: map[string]map[string]Define{}}
+}
+
+// Load loads every define from the game's defines directory
+func Load() (*Defines, error) {
+	dfs, err := dirs.Defines.Files()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFiles(dfs)
+}
+
+// LoadFrom loads every define from the defines directory of the root
+func LoadFrom(g dirs.Root) (*Defines, error) {
+	srcs, err := g.Files(dirs.Defines)
+	if err != nil {
+		return nil, err
+	}
+	return LoadFiles(srcs)
+}
+
+// LoadFiles merges the defines of each file in order
+func LoadFiles[S files.Source](srcs []S) (*Defines, error) {
+	d := newDefines()
+	for _, src := range srcs {
+		file, err := parser.ParseSource(src)
+		if err != nil {
+			return nil, err
+		}
+		if err := d.addFile(file, src.Origin()); err != nil {
+			return nil, err
+		}
+	}
+	return d, nil
+}
+
+func (d *Defines) addFile(file *parser.File, origin string) error {
+	for _, item := range file.Items {
+		ns, ok := item.(*parser.Field)
+		var blk *parser.Block
+		if ok {
+			blk, ok = ns.Value.(*parser.Block)
+		}
+		if !ok {
+			return fmt.Errorf("%s:%d:%d: expected a namespace of the form NName = { ... }", file.Name, item.Start().Line(), item.Start().Col())
+		}
+
+		for _, nested := range blk.Items {
+			f, ok := nested.(*parser.Field)
+			if !ok {
+				return fmt.Errorf("%s:%d:%d: expected a define of the form KEY = value", file.Name, nested.Start().Line(), nested.Start().Col())
+			}
+			d.add(Define{
+				Namespace: ns.Name(),
+				Key:       f.Name(),
+				Value:     f.Value,
+				Source:    entity.Source{File: file.Name, Pos: f.Start(), Origin: origin},
+			})
+		}
+	}
+	return nil
+}
+
+func (d *Defines) add(def Define) {
+	ns, ok := d.values[def.Namespace]
+	if !ok {
+		ns = map[string]Define{}
+		d.values[def.Namespace] = ns
+		d.namespaces = append(d.namespaces, def.Namespace)
+	}
+	if prev, ok := ns[def.Key]; ok {
+		d.overrides = append(d.overrides, Override{Previous: prev, Current: def})
+	} else {
+		d.keys[def.Namespace] = append(d.keys[def.Namespace], def.Key)
+	}
+	ns[def.Key] = def
+}
+
+// Get returns the define, e.g. Get("NEconomy", "KEY")
+func (d *Defines) Get(namespace, key string) (Define, bool) {
+	def, ok := d.values[namespace][key]
+	return def, ok
+}
+
+func (d *Defines) lookup(namespace, key string) (Define, error) {
+	def, ok := d.Get(namespace, key)
+	if !ok {
+		return def, fmt.Errorf("undefined define %s.%s", namespace, key)
+	}
+	return def, nil
+}
+
+func (d *Defines) Float(namespace, key string) (float64, error) {
+	def, err := d.lookup(namespace, key)
+	if err != nil {
+		return 0, err
+	}
+	return def.Float()
+}
+
+func (d *Defines) Int(namespace, key string) (int, error) {
+	def, err := d.lookup(namespace, key)
+	if err != nil {
+		return 0, err
+	}
+	return def.Int()
+}
+
+func (d *Defines) Text(namespace, key string) (string, error) {
+	def, err := d.lookup(namespace, key)
+	if err != nil {
+		return "", err
+	}
+	return def.Text()
+}
+
+func (d *Defines) List(namespace, key string) ([]string, error) {
+	def, err := d.lookup(namespace, key)
+	if err != nil {
+		return nil, err
+	}
+	return def.List()
+}
+
+// Namespaces returns every namespace, in order of first definition
+func (d *Defines) Namespaces() []string {
+	return d.namespaces
+}
+
+// Keys returns every key in the namespace, in order of first definition
+func (d *Defines) Keys(namespace string) []string {
+	return d.keys[namespace]
+}
+
+// Overrides returns every define which replaced an earlier value, in load order
+func (d *Defines) Overrides() []Override {
+	return d.overrides
+}
@@ -0,0 +1,111 @@
+package defines
+
+import (
+	"slices"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	Base           files.DataFile = "testdata/00_defines.txt"
+	OverrideFile   files.DataFile = "testdata/01_override.txt"
+	NotANamespace  files.DataFile = "testdata/not-a-namespace.txt"
+	ExpectedNSKeys                = 6
+)
+
+func load(t *testing.T) *Defines {
+	d, err := LoadFiles([]files.DataFile{Base, OverrideFile})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return d
+}
+
+func TestLoadFiles_merges(t *testing.T) {
+	d := load(t)
+	if !slices.Equal(d.Namespaces(), []string{"NEconomy", "NMilitary", "NPolitics"}) {
+		t.Errorf("unexpected namespaces: %v", d.Namespaces())
+	}
+	if keys := d.Keys("NEconomy"); len(keys) != ExpectedNSKeys || keys[5] != "NEW_KEY" {
+		t.Errorf("unexpected NEconomy keys: %v", keys)
+	}
+}
+
+func TestGet_typed(t *testing.T) {
+	d := load(t)
+
+	if v, err := d.Int("NEconomy", "BASE_CONSTRUCTION_COST"); err != nil || v != 100 {
+		t.Errorf("expected: %d, actual: %d (%v)", 100, v, err)
+	}
+	if v, err := d.Float("NEconomy", "MIN_PRICE_MULT"); err != nil || v != 0.2 {
+		t.Errorf("expected later file to override; expected: %v, actual: %v (%v)", 0.2, v, err)
+	}
+	if v, err := d.Text("NEconomy", "CURRENCY"); err != nil || v != "GBP" {
+		t.Errorf("expected: %s, actual: %s (%v)", "GBP", v, err)
+	}
+	if v, err := d.List("NPolitics", "TAGS"); err != nil || !slices.Equal(v, []string{"GBR", "FRA"}) {
+		t.Errorf("expected: %v, actual: %v (%v)", []string{"GBR", "FRA"}, v, err)
+	}
+
+	tiers, _ := d.Get("NEconomy", "COST_TIERS")
+	if v, err := tiers.Floats(); err != nil || !slices.Equal(v, []float64{1, 2.5, 4}) {
+		t.Errorf("expected: %v, actual: %v (%v)", []float64{1, 2.5, 4}, v, err)
+	}
+}
+
+func TestDefine_Kind(t *testing.T) {
+	d := load(t)
+	expected := map[string]Kind{
+		"BASE_CONSTRUCTION_COST": Int,
+		"MIN_PRICE_MULT":         Float,
+		"COST_TIERS":             List,
+		"CURRENCY":               String,
+		"DEFAULT_GOOD":           String,
+	}
+	for key, kind := range expected {
+		def, _ := d.Get("NEconomy", key)
+		if def.Kind() != kind {
+			t.Errorf("%s: expected: %s, actual: %s", key, kind, def.Kind())
+		}
+	}
+}
+
+func TestGet_wrongTypeReturnsError(t *testing.T) {
+	d := load(t)
+	if _, err := d.Int("NEconomy", "MIN_PRICE_MULT"); err == nil {
+		t.Errorf("Int did not return an error for a float")
+	}
+	if _, err := d.Float("NEconomy", "CURRENCY"); err == nil {
+		t.Errorf("Float did not return an error for a string")
+	}
+	if _, err := d.List("NEconomy", "CURRENCY"); err == nil {
+		t.Errorf("List did not return an error for a string")
+	}
+	if _, err := d.Float("NEconomy", "DOES_NOT_EXIST"); err == nil {
+		t.Errorf("Float did not return an error for an undefined define")
+	}
+}
+
+func TestOverrides(t *testing.T) {
+	overrides := load(t).Overrides()
+	if len(overrides) != 2 {
+		t.Fatalf("expected %d overrides, actual: %d", 2, len(overrides))
+	}
+
+	o := overrides[0]
+	if o.Current.Key != "MIN_PRICE_MULT" {
+		t.Errorf("expected: %s, actual: %s", "MIN_PRICE_MULT", o.Current.Key)
+	}
+	if o.Previous.Source.String() != "testdata/00_defines.txt:3:2" {
+		t.Errorf("expected: %s, actual: %s", "testdata/00_defines.txt:3:2", o.Previous.Source)
+	}
+	if o.Current.Source.String() != "testdata/01_override.txt:2:2" {
+		t.Errorf("expected: %s, actual: %s", "testdata/01_override.txt:2:2", o.Current.Source)
+	}
+}
+
+func TestLoadFiles_notANamespaceReturnsError(t *testing.T) {
+	if _, err := LoadFiles([]files.DataFile{NotANamespace}); err == nil {
+		t.Errorf("LoadFiles did not return an error for a define outside a namespace")
+	}
+}
@@ -0,0 +1,158 @@
+package entity
+
+import (
+	"fmt"
+	"vic3-data-reader/internal/read/decode"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Source is where an entity is defined
+type Source struct {
+	File string
+	Pos  files.Position
+	// Origin is the game or mod which supplied the file, if known
+	Origin string
+}
+
+func (s Source) Line() int {
+	return s.Pos.Line()
+}
+
+// At returns the source moved to another position in the same file, e.g. that of a Ref within the definition
+func (s Source) At(pos files.Position) Source {
+	s.Pos = pos
+	return s
+}
+
+func (s Source) String() string {
+	if s.Origin != "" {
+		return fmt.Sprintf("%s:%d:%d (%s)", s.File, s.Pos.Line(), s.Pos.Col(), s.Origin)
+	}
+	return fmt.Sprintf("%s:%d:%d", s.File, s.Pos.Line(), s.Pos.Col())
+}
+
+// Entry is a single top level definition, e.g. `ammunition = { ... }`
+type Entry[T any] struct {
+	Key    string
+	Value  T
+	Source Source
+}
+
+// Collection is a set of entities keyed by name, kept in definition order
+type Collection[T any] struct {
+	entries []*Entry[T]
+	index   map[string]int
+}
+
+func NewCollection[T any]() *Collection[T] {
+	return &Collection[T]{index: map[string]int{}}
+}
+
+// Add adds an entity to the end of the collection.
+// If the key is already defined, the value and source are replaced but the original order is kept.
+func (c *Collection[T]) Add(key string, value T, src Source) {
+	entry := &Entry[T]{Key: key, Value: value, Source: src}
+	if i, ok := c.index[key]; ok {
+		c.entries[i] = entry
+		return
+	}
+	c.index[key] = len(c.entries)
+	c.entries = append(c.entries, entry)
+}
+
+func (c *Collection[T]) Get(key string) (T, bool) {
+	entry, ok := c.Entry(key)
+	if !ok {
+		var zero T
+		return zero, false
+	}
+	return entry.Value, true
+}
+
+func (c *Collection[T]) Entry(key string) (*Entry[T], bool) {
+	i, ok := c.index[key]
+	if !ok {
+		return nil, false
+	}
+	return c.entries[i], true
+}
+
+// Entries returns every entry in definition order
+func (c *Collection[T]) Entries() []*Entry[T] {
+	return c.entries
+}
+
+// Keys returns every key in definition order
+func (c *Collection[T]) Keys() []string {
+	keys := make([]string, len(c.entries))
+	for i, entry := range c.entries {
+		keys[i] = entry.Key
+	}
+	return keys
+}
+
+// Values returns every value in definition order
+func (c *Collection[T]) Values() []T {
+	values := make([]T, len(c.entries))
+	for i, entry := range c.entries {
+		values[i] = entry.Value
+	}
+	return values
+}
+
+func (c *Collection[T]) Len() int {
+	return len(c.entries)
+}
+
+// LoadDir loads every file in the DataDir into one collection
+func LoadDir[T any](d dirs.DataDir, opts ...dirs.ListOption) (*Collection[T], error) {
+	dfs, err := d.Files(opts...)
+	if err != nil {
+		return nil, err
+	}
+	return LoadFiles[T](dfs)
+}
+
+// LoadInstall loads every file in the DataDir of the root into one collection
+func LoadInstall[T any](g dirs.Root, d dirs.DataDir, opts ...dirs.ListOption) (*Collection[T], error) {
+	srcs, err := g.Files(d, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return LoadFiles[T](srcs)
+}
+
+// LoadFiles decodes each top level field of each file into a T.
+// Files are loaded in order, so later files override earlier definitions of the same key.
+func LoadFiles[T any, S files.Source](srcs []S) (*Collection[T], error) {
+	c := NewCollection[T]()
+	for _, src := range srcs {
+		file, err := parser.ParseSource(src)
+		if err != nil {
+			return nil, err
+		}
+		if err := c.addFile(file, src.Origin()); err != nil {
+			return nil, err
+		}
+	}
+	return c, nil
+}
+
+func (c *Collection[T]) addFile(file *parser.File, origin string) error {
+	dec := decode.NewDecoder()
+	for _, item := range file.Items {
+		f, ok := item.(*parser.Field)
+		if !ok {
+			return fmt.Errorf("%s:%d:%d: expected a definition of the form key = { ... }", file.Name, item.Start().Line(), item.Start().Col())
+		}
+
+		var value T
+		if err := dec.DecodeField(f, &value); err != nil {
+			return fmt.Errorf("%s:%w", file.Name, err)
+		}
+		c.Add(f.Name(), value, Source{File: file.Name, Pos: f.Start(), Origin: origin})
+	}
+	return nil
+}
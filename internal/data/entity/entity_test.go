@@ -0,0 +1,102 @@
+package entity
+
+import (
+	"testing"
+	"testing/fstest"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	Base            files.DataFile = "testdata/00_base.txt"
+	Override        files.DataFile = "testdata/01_override.txt"
+	NotADefinition  files.DataFile = "testdata/not-a-definition.txt"
+	DoesNotExist    files.DataFile = "testdata/DOES-NOT-EXIST.txt"
+	ExpectedEntries                = 3
+)
+
+type item struct {
+	Value int `pdx:"value"`
+}
+
+func TestLoadFiles_order(t *testing.T) {
+	c, err := LoadFiles[item]([]files.DataFile{Base, Override})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	if c.Len() != ExpectedEntries {
+		t.Fatalf("expected %d entries, actual: %d", ExpectedEntries, c.Len())
+	}
+
+	expected := []string{"first", "second", "third"}
+	for i, key := range c.Keys() {
+		if key != expected[i] {
+			t.Errorf("expected: %s, actual: %s", expected[i], key)
+		}
+	}
+}
+
+func TestLoadFiles_laterFilesOverride(t *testing.T) {
+	c, err := LoadFiles[item]([]files.DataFile{Base, Override})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+
+	first, ok := c.Entry("first")
+	if !ok {
+		t.Fatalf("missing entry %q", "first")
+	}
+	if first.Value.Value != 10 {
+		t.Errorf("expected: %d, actual: %d", 10, first.Value.Value)
+	}
+	if first.Source.File != string(Override) {
+		t.Errorf("expected source file: %s, actual: %s", Override, first.Source.File)
+	}
+	if first.Source.Line() != 2 {
+		t.Errorf("expected source line: %d, actual: %d", 2, first.Source.Line())
+	}
+}
+
+func TestLoadFiles_notADefinitionReturnsError(t *testing.T) {
+	_, err := LoadFiles[item]([]files.DataFile{NotADefinition})
+	if err == nil {
+		t.Errorf("LoadFiles did not return an error for a top level value")
+	}
+}
+
+func TestLoadFiles_errOnMissingFile(t *testing.T) {
+	_, err := LoadFiles[item]([]files.DataFile{DoesNotExist})
+	if err == nil {
+		t.Errorf("LoadFiles did not return an error for missing file")
+	}
+}
+
+func TestGet_missingKey(t *testing.T) {
+	c := NewCollection[item]()
+	_, ok := c.Get("missing")
+	if ok {
+		t.Errorf("Get returned ok for missing key")
+	}
+}
+
+func TestLoadInstall(t *testing.T) {
+	g := dirs.NewGameInstall(fstest.MapFS{
+		"game/common/goods/00_base.txt":     &fstest.MapFile{Data: []byte("first = { value = 1 }\nsecond = { value = 2 }\n")},
+		"game/common/goods/01_override.txt": &fstest.MapFile{Data: []byte("first = { value = 10 }\n")},
+	})
+	c, err := LoadInstall[item](g, dirs.Goods)
+	if err != nil {
+		t.Fatalf("LoadInstall returned unexpected error: %v", err)
+	}
+	if c.Len() != 2 {
+		t.Fatalf("expected %d entries, actual: %d", 2, c.Len())
+	}
+
+	first, _ := c.Entry("first")
+	if first.Value.Value != 10 {
+		t.Errorf("expected: %d, actual: %d", 10, first.Value.Value)
+	}
+	if first.Source.String() != "game/common/goods/01_override.txt:1:1" {
+		t.Errorf("expected: %s, actual: %s", "game/common/goods/01_override.txt:1:1", first.Source)
+	}
+}
@@ -0,0 +1,27 @@
+package entity
+
+import (
+	"fmt"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Ref is a key referring to another entity, e.g. an entry of unlocking_technologies.
+// The position it is written at is kept, so an undefined reference can be reported where it is made.
+type Ref struct {
+	Key string
+	Pos files.Position
+}
+
+func (r *Ref) UnmarshalPDX(val parser.Value) error {
+	s, ok := val.(*parser.Scalar)
+	if !ok {
+		return fmt.Errorf("%d:%d: expected a key", val.Start().Line(), val.Start().Col())
+	}
+	r.Key, r.Pos = s.Text(), s.Start()
+	return nil
+}
+
+func (r Ref) String() string {
+	return r.Key
+}
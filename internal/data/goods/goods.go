@@ -0,0 +1,66 @@
+package goods
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+type Category string
+
+const (
+	Military   Category = "military"
+	Staple     Category = "staple"
+	Industrial Category = "industrial"
+	Luxury     Category = "luxury"
+)
+
+// Good is a single entry in common/goods
+type Good struct {
+	Key                                string   `pdx:",key"`
+	Texture                            string   `pdx:"texture"`
+	Cost                               float64  `pdx:"cost"`
+	Category                           Category `pdx:"category"`
+	PrestigeFactor                     float64  `pdx:"prestige_factor"`
+	TradedQuantity                     float64  `pdx:"traded_quantity"`
+	ObsessionChance                    float64  `pdx:"obsession_chance"`
+	ConvoyCostMultiplier               float64  `pdx:"convoy_cost_multiplier"`
+	ConsumptionTaxCost                 float64  `pdx:"consumption_tax_cost"`
+	Local                              bool     `pdx:"local"`
+	Tradeable                          *bool    `pdx:"tradeable"`
+	FixedPrice                         bool     `pdx:"fixed_price"`
+	PopConsumptionCanAddInfrastructure bool     `pdx:"pop_consumption_can_add_infrastructure"`
+}
+
+// IsTradeable is true unless the good sets `tradeable = no`
+func (g Good) IsTradeable() bool {
+	return g.Tradeable == nil || *g.Tradeable
+}
+
+// Goods are kept in the order the game defines them
+type Goods = entity.Collection[Good]
+
+// Load loads every good from the game's goods directory
+func Load() (*Goods, error) {
+	return entity.LoadDir[Good](dirs.Goods)
+}
+
+// LoadFrom loads every good from the goods directory of the root
+func LoadFrom(g dirs.Root) (*Goods, error) {
+	return entity.LoadInstall[Good](g, dirs.Goods)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*Goods, error) {
+	return entity.LoadFiles[Good](srcs)
+}
+
+// ByCategory returns the goods in the category, in definition order
+func ByCategory(goods *Goods, cat Category) []Good {
+	var matches []Good
+	for _, g := range goods.Values() {
+		if g.Category == cat {
+			matches = append(matches, g)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,128 @@
+package goods
+
+import (
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	SmokeSample   files.DataFile = "testdata/00_goods.txt"
+	ExpectedGoods                = 13
+)
+
+func load(t *testing.T) *Goods {
+	goods, err := LoadFiles([]files.DataFile{SmokeSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return goods
+}
+
+func TestLoadFiles_count(t *testing.T) {
+	goods := load(t)
+	if goods.Len() != ExpectedGoods {
+		t.Errorf("expected %d goods, actual: %d", ExpectedGoods, goods.Len())
+	}
+}
+
+func TestLoadFiles_definitionOrder(t *testing.T) {
+	keys := load(t).Keys()
+	expected := []string{"ammunition", "small_arms", "artillery", "tanks", "aeroplanes"}
+	for i, key := range expected {
+		if keys[i] != key {
+			t.Errorf("expected: %s, actual: %s", key, keys[i])
+		}
+	}
+	if last := keys[len(keys)-1]; last != "fine_art" {
+		t.Errorf("expected: %s, actual: %s", "fine_art", last)
+	}
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	artillery, ok := load(t).Get("artillery")
+	if !ok {
+		t.Fatalf("missing good %q", "artillery")
+	}
+
+	if artillery.Key != "artillery" {
+		t.Errorf("expected: %s, actual: %s", "artillery", artillery.Key)
+	}
+	if artillery.Texture != "gfx/interface/icons/goods_icons/artillery.dds" {
+		t.Errorf("unexpected texture: %s", artillery.Texture)
+	}
+	if artillery.Cost != 70 {
+		t.Errorf("expected: %v, actual: %v", 70, artillery.Cost)
+	}
+	if artillery.Category != Military {
+		t.Errorf("expected: %s, actual: %s", Military, artillery.Category)
+	}
+	if artillery.PrestigeFactor != 5 {
+		t.Errorf("expected: %v, actual: %v", 5, artillery.PrestigeFactor)
+	}
+	if artillery.TradedQuantity != 3.5 {
+		t.Errorf("expected: %v, actual: %v", 3.5, artillery.TradedQuantity)
+	}
+	if artillery.ConvoyCostMultiplier != 1.5 {
+		t.Errorf("expected: %v, actual: %v", 1.5, artillery.ConvoyCostMultiplier)
+	}
+}
+
+func TestLoadFiles_optionalFields(t *testing.T) {
+	goods := load(t)
+
+	grain, _ := goods.Get("grain")
+	if grain.ConsumptionTaxCost != 500 {
+		t.Errorf("expected: %v, actual: %v", 500, grain.ConsumptionTaxCost)
+	}
+	if !grain.IsTradeable() {
+		t.Errorf("grain should be tradeable by default")
+	}
+
+	smallArms, _ := goods.Get("small_arms")
+	if smallArms.ObsessionChance != 0.5 {
+		t.Errorf("expected: %v, actual: %v", 0.5, smallArms.ObsessionChance)
+	}
+
+	var local, untradeable int
+	for _, g := range goods.Values() {
+		if g.Local {
+			local++
+		}
+		if !g.IsTradeable() {
+			untradeable++
+		}
+	}
+	if local != 3 {
+		t.Errorf("expected %d local goods, actual: %d", 3, local)
+	}
+	if untradeable != 1 {
+		t.Errorf("expected %d untradeable goods, actual: %d", 1, untradeable)
+	}
+}
+
+func TestLoadFiles_source(t *testing.T) {
+	goods := load(t)
+
+	ammunition, _ := goods.Entry("ammunition")
+	smallArms, _ := goods.Entry("small_arms")
+	if ammunition.Source.File != string(SmokeSample) {
+		t.Errorf("expected: %s, actual: %s", SmokeSample, ammunition.Source.File)
+	}
+	// ammunition is the first definition, after the header comments
+	if ammunition.Source.Line() != 9 {
+		t.Errorf("expected line: %d, actual: %d", 9, ammunition.Source.Line())
+	}
+	if smallArms.Source.Line() != 18 {
+		t.Errorf("expected line: %d, actual: %d", 18, smallArms.Source.Line())
+	}
+}
+
+func TestByCategory(t *testing.T) {
+	military := ByCategory(load(t), Military)
+	if len(military) != 7 {
+		t.Errorf("expected %d military goods, actual: %d", 7, len(military))
+	}
+	if military[0].Key != "ammunition" {
+		t.Errorf("expected: %s, actual: %s", "ammunition", military[0].Key)
+	}
+}
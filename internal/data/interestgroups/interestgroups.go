@@ -0,0 +1,91 @@
+package interestgroups
+
+import (
+	"slices"
+	"strconv"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// InterestGroup is a single entry in common/interest_groups.
+//
+// Weights and chances may be numbers or script blocks, so they are kept as raw values;
+// use Constant to read the ones which are plain numbers.
+type InterestGroup struct {
+	Key                    string        `pdx:",key"`
+	Color                  parser.Value  `pdx:"color"`
+	Texture                string        `pdx:"texture"`
+	Layer                  string        `pdx:"layer"`
+	Index                  int           `pdx:"index"`
+	Ideologies             []string      `pdx:"ideologies"`
+	CharacterIdeologies    []string      `pdx:"character_ideologies"`
+	Traits                 []string      `pdx:"traits"`
+	Enable                 *parser.Block `pdx:"enable"`
+	PopWeight              *parser.Block `pdx:"pop_weight"`
+	MonarchWeight          parser.Value  `pdx:"monarch_weight"`
+	AgitatorWeight         parser.Value  `pdx:"agitator_weight"`
+	CommanderWeight        parser.Value  `pdx:"commander_weight"`
+	NobleChance            parser.Value  `pdx:"noble_chance"`
+	FemaleCommanderChance  parser.Value  `pdx:"female_commander_chance"`
+	FemalePoliticianChance parser.Value  `pdx:"female_politician_chance"`
+	FemaleAgitatorChance   parser.Value  `pdx:"female_agitator_chance"`
+}
+
+// InterestGroups are kept in the order the game defines them
+type InterestGroups = entity.Collection[InterestGroup]
+
+// Load loads every interest group from the game's interest groups directory
+func Load() (*InterestGroups, error) {
+	return entity.LoadDir[InterestGroup](dirs.InterestGroups)
+}
+
+// LoadFrom loads every interest group from the interest groups directory of the root
+func LoadFrom(g dirs.Root) (*InterestGroups, error) {
+	return entity.LoadInstall[InterestGroup](g, dirs.InterestGroups)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*InterestGroups, error) {
+	return entity.LoadFiles[InterestGroup](srcs)
+}
+
+// Constant returns the number a weight or chance is fixed at.
+// This is either a plain number, or a block whose only field is `value = <number>`.
+func Constant(v parser.Value) (float64, bool) {
+	switch val := v.(type) {
+	case *parser.Scalar:
+		n, err := strconv.ParseFloat(val.Text(), 64)
+		return n, err == nil
+	case *parser.Block:
+		fields := val.Fields()
+		if len(fields) != 1 || len(val.Items) != 1 || fields[0].Name() != "value" {
+			return 0, false
+		}
+		return Constant(fields[0].Value)
+	default:
+		return 0, false
+	}
+}
+
+// WithIdeology returns the interest groups which start with the ideology, in definition order
+func WithIdeology(igs *InterestGroups, ideology string) []InterestGroup {
+	var matches []InterestGroup
+	for _, ig := range igs.Values() {
+		if slices.Contains(ig.Ideologies, ideology) {
+			matches = append(matches, ig)
+		}
+	}
+	return matches
+}
+
+// WithTrait returns the interest groups which have the trait, in definition order
+func WithTrait(igs *InterestGroups, trait string) []InterestGroup {
+	var matches []InterestGroup
+	for _, ig := range igs.Values() {
+		if slices.Contains(ig.Traits, trait) {
+			matches = append(matches, ig)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,81 @@
+package interestgroups
+
+import (
+	"slices"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	SmokeSample            files.DataFile = "testdata/00_interest_groups.txt"
+	ExpectedInterestGroups                = 2
+)
+
+func load(t *testing.T) *InterestGroups {
+	igs, err := LoadFiles([]files.DataFile{SmokeSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return igs
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	igs := load(t)
+	if igs.Len() != ExpectedInterestGroups {
+		t.Fatalf("expected %d interest groups, actual: %d", ExpectedInterestGroups, igs.Len())
+	}
+
+	armedForces, _ := igs.Get("ig_armed_forces")
+	expectedIdeologies := []string{"ideology_patriotic", "ideology_jingoist", "ideology_loyalist"}
+	if !slices.Equal(armedForces.Ideologies, expectedIdeologies) {
+		t.Errorf("expected: %v, actual: %v", expectedIdeologies, armedForces.Ideologies)
+	}
+	if len(armedForces.Traits) != 3 || armedForces.Traits[1] != "ig_trait_officer_corps" {
+		t.Errorf("unexpected traits: %v", armedForces.Traits)
+	}
+	if armedForces.Layer != "revolution_dynamic_armed_forces" || armedForces.Index != 0 {
+		t.Errorf("unexpected ig_armed_forces: %+v", armedForces)
+	}
+	if armedForces.PopWeight == nil || armedForces.Enable == nil {
+		t.Errorf("expected pop_weight and enable to be kept as raw blocks")
+	}
+}
+
+func TestConstant(t *testing.T) {
+	igs := load(t)
+	armedForces, _ := igs.Get("ig_armed_forces")
+	landowners, _ := igs.Get("ig_landowners")
+
+	if v, ok := Constant(armedForces.FemalePoliticianChance); !ok || v != 0.05 {
+		t.Errorf("expected female_politician_chance = 0.05, actual: %v", v)
+	}
+	if v, ok := Constant(armedForces.MonarchWeight); !ok || v != 1 {
+		t.Errorf("expected monarch_weight = { value = 1.0 } to be constant, actual: %v", v)
+	}
+	if _, ok := Constant(armedForces.NobleChance); ok {
+		t.Errorf("expected conditional noble_chance not to be constant")
+	}
+	if v, ok := Constant(landowners.NobleChance); !ok || v != 0.9 {
+		t.Errorf("expected noble_chance = 0.9, actual: %v", v)
+	}
+	if _, ok := Constant(landowners.AgitatorWeight); ok {
+		t.Errorf("expected missing agitator_weight not to be constant")
+	}
+}
+
+func TestWithIdeology(t *testing.T) {
+	igs := load(t)
+	if patriotic := WithIdeology(igs, "ideology_patriotic"); len(patriotic) != 2 {
+		t.Errorf("expected %d interest groups, actual: %d", 2, len(patriotic))
+	}
+	if jingoist := WithIdeology(igs, "ideology_jingoist"); len(jingoist) != 1 || jingoist[0].Key != "ig_armed_forces" {
+		t.Errorf("unexpected interest groups: %v", jingoist)
+	}
+}
+
+func TestWithTrait(t *testing.T) {
+	matches := WithTrait(load(t), "ig_trait_family_ties")
+	if len(matches) != 1 || matches[0].Key != "ig_landowners" {
+		t.Errorf("unexpected interest groups: %v", matches)
+	}
+}
@@ -0,0 +1,98 @@
+package laws
+
+import (
+	"slices"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/data/modifiers"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Law is a single entry in common/laws
+type Law struct {
+	Key                        string              `pdx:",key"`
+	Group                      string              `pdx:"group"`
+	Icon                       string              `pdx:"icon"`
+	Progressiveness            float64             `pdx:"progressiveness"`
+	Modifier                   modifiers.Modifiers `pdx:"modifier"`
+	Institution                string              `pdx:"institution"`
+	InstitutionModifier        modifiers.Modifiers `pdx:"institution_modifier"`
+	UnlockingTechnologies      []string            `pdx:"unlocking_technologies"`
+	UnlockingLaws              []string            `pdx:"unlocking_laws"`
+	DisallowingLaws            []string            `pdx:"disallowing_laws"`
+	PossiblePoliticalMovements []string            `pdx:"possible_political_movements"`
+	IsVisible                  *parser.Block       `pdx:"is_visible"`
+	CanEnact                   *parser.Block       `pdx:"can_enact"`
+	AIWillDo                   *parser.Block       `pdx:"ai_will_do"`
+}
+
+// LawGroup is a single entry in common/law_groups.
+// A country always has exactly one law of each group enacted.
+type LawGroup struct {
+	Key               string `pdx:",key"`
+	Category          string `pdx:"law_group_category"`
+	BaseEnactmentDays int    `pdx:"base_enactment_days"`
+}
+
+// Laws are kept in the order the game defines them
+type Laws = entity.Collection[Law]
+
+// LawGroups are kept in the order the game defines them
+type LawGroups = entity.Collection[LawGroup]
+
+// Load loads every law from the game's laws directory
+func Load() (*Laws, error) {
+	return entity.LoadDir[Law](dirs.Laws)
+}
+
+// LoadFrom loads every law from the laws directory of the root
+func LoadFrom(g dirs.Root) (*Laws, error) {
+	return entity.LoadInstall[Law](g, dirs.Laws)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*Laws, error) {
+	return entity.LoadFiles[Law](srcs)
+}
+
+// LoadGroups loads every law group from the game's law groups directory
+func LoadGroups() (*LawGroups, error) {
+	return entity.LoadDir[LawGroup](dirs.LawGroups)
+}
+
+// LoadGroupsFrom loads every law group from the law groups directory of the root
+func LoadGroupsFrom(g dirs.Root) (*LawGroups, error) {
+	return entity.LoadInstall[LawGroup](g, dirs.LawGroups)
+}
+
+func LoadGroupsFiles[S files.Source](srcs []S) (*LawGroups, error) {
+	return entity.LoadFiles[LawGroup](srcs)
+}
+
+// InGroup returns the laws in the group, in definition order
+func InGroup(laws *Laws, group string) []Law {
+	var matches []Law
+	for _, l := range laws.Values() {
+		if l.Group == group {
+			matches = append(matches, l)
+		}
+	}
+	return matches
+}
+
+// ByInstitution returns the laws which establish the institution, in definition order
+func ByInstitution(laws *Laws, institution string) []Law {
+	var matches []Law
+	for _, l := range laws.Values() {
+		if l.Institution == institution {
+			matches = append(matches, l)
+		}
+	}
+	return matches
+}
+
+// Disallows reports whether either law lists the other in disallowing_laws,
+// meaning the two can't be enacted at the same time.
+func Disallows(a, b Law) bool {
+	return slices.Contains(a.DisallowingLaws, b.Key) || slices.Contains(b.DisallowingLaws, a.Key)
+}
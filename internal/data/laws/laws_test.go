@@ -0,0 +1,97 @@
+package laws
+
+import (
+	"slices"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	LawsSample      files.DataFile = "testdata/00_laws.txt"
+	LawGroupsSample files.DataFile = "testdata/00_law_groups.txt"
+	ExpectedLaws                   = 6
+)
+
+func load(t *testing.T) *Laws {
+	laws, err := LoadFiles([]files.DataFile{LawsSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return laws
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	laws := load(t)
+	if laws.Len() != ExpectedLaws {
+		t.Fatalf("expected %d laws, actual: %d", ExpectedLaws, laws.Len())
+	}
+
+	monarchy, _ := laws.Get("law_monarchy")
+	if monarchy.Group != "lawgroup_governance_principles" {
+		t.Errorf("expected: %s, actual: %s", "lawgroup_governance_principles", monarchy.Group)
+	}
+	if v, ok := monarchy.Modifier.Get("country_legitimacy_headofstate_add"); !ok || v != 20 {
+		t.Errorf("expected country_legitimacy_headofstate_add = 20, actual: %v", v)
+	}
+	if len(monarchy.PossiblePoliticalMovements) != 2 {
+		t.Errorf("expected 2 possible political movements, actual: %v", monarchy.PossiblePoliticalMovements)
+	}
+
+	republic, _ := laws.Get("law_presidential_republic")
+	if !slices.Equal(republic.UnlockingTechnologies, []string{"egalitarianism"}) {
+		t.Errorf("expected: %v, actual: %v", []string{"egalitarianism"}, republic.UnlockingTechnologies)
+	}
+
+	poorLaws, _ := laws.Get("law_poor_laws")
+	if poorLaws.Institution != "institution_social_security" {
+		t.Errorf("expected: %s, actual: %s", "institution_social_security", poorLaws.Institution)
+	}
+	if v, ok := poorLaws.InstitutionModifier.Get("state_pop_qualifications_mult"); !ok || v != 0.05 {
+		t.Errorf("expected state_pop_qualifications_mult = 0.05, actual: %v", v)
+	}
+	if len(poorLaws.UnlockingLaws) != 2 || poorLaws.CanEnact == nil {
+		t.Errorf("unexpected law_poor_laws: %+v", poorLaws)
+	}
+}
+
+func TestLoadGroupsFiles(t *testing.T) {
+	groups, err := LoadGroupsFiles([]files.DataFile{LawGroupsSample})
+	if err != nil {
+		t.Fatalf("LoadGroupsFiles returned unexpected error: %v", err)
+	}
+	if groups.Len() != 3 {
+		t.Fatalf("expected %d law groups, actual: %d", 3, groups.Len())
+	}
+	slavery, _ := groups.Get("lawgroup_slavery")
+	if slavery.Category != "human_rights" || slavery.BaseEnactmentDays != 150 {
+		t.Errorf("unexpected lawgroup_slavery: %+v", slavery)
+	}
+}
+
+func TestInGroup(t *testing.T) {
+	welfare := InGroup(load(t), "lawgroup_welfare")
+	if len(welfare) != 2 || welfare[0].Key != "law_poor_laws" || welfare[1].Key != "law_wage_protections" {
+		t.Errorf("unexpected welfare laws: %v", welfare)
+	}
+}
+
+func TestByInstitution(t *testing.T) {
+	laws := ByInstitution(load(t), "institution_social_security")
+	if len(laws) != 2 {
+		t.Errorf("expected %d laws, actual: %d", 2, len(laws))
+	}
+}
+
+func TestDisallows(t *testing.T) {
+	laws := load(t)
+	slavery, _ := laws.Get("law_legacy_slavery")
+	poorLaws, _ := laws.Get("law_poor_laws")
+	monarchy, _ := laws.Get("law_monarchy")
+
+	if !Disallows(slavery, poorLaws) || !Disallows(poorLaws, slavery) {
+		t.Errorf("expected law_legacy_slavery and law_poor_laws to disallow each other")
+	}
+	if Disallows(slavery, monarchy) {
+		t.Errorf("expected law_legacy_slavery and law_monarchy to be compatible")
+	}
+}
@@ -0,0 +1,162 @@
+package localization
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"slices"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+)
+
+// Expectation is the localization every entity of a DataDir should have
+type Expectation struct {
+	Dir dirs.DataDir
+	// Suffixes are appended to each entity key, where "" expects the key itself, e.g. {"", "_desc"}
+	Suffixes []string
+}
+
+// DefaultExpectations are the names and descriptions the game shows for the entities this module loads
+var DefaultExpectations = []Expectation{
+	{Dir: dirs.BuildingGroups, Suffixes: []string{""}},
+	{Dir: dirs.Buildings, Suffixes: []string{"", "_desc"}},
+	{Dir: dirs.Goods, Suffixes: []string{"", "_desc"}},
+	{Dir: dirs.InterestGroups, Suffixes: []string{"", "_desc"}},
+	{Dir: dirs.LawGroups, Suffixes: []string{"", "_desc"}},
+	{Dir: dirs.Laws, Suffixes: []string{"", "_desc"}},
+	{Dir: dirs.PopTypes, Suffixes: []string{""}},
+	{Dir: dirs.ProductionMethodGroups, Suffixes: []string{""}},
+	{Dir: dirs.ProductionMethods, Suffixes: []string{""}},
+	{Dir: dirs.Technologies, Suffixes: []string{"", "_desc"}},
+}
+
+// ExpectedKey is a localization key which should be defined in every language
+type ExpectedKey struct {
+	Key string
+	// Source is the definition of the entity which needs the key
+	Source entity.Source
+}
+
+// ExpectedKeys loads the entity keys of each expectation's DataDir from the root, and appends the suffixes.
+// A DataDir which the root does not have is skipped.
+func ExpectedKeys(g dirs.Root, expectations []Expectation) ([]ExpectedKey, error) {
+	var keys []ExpectedKey
+	for _, exp := range expectations {
+		c, err := entity.LoadInstall[struct{}](g, exp.Dir)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, entry := range c.Entries() {
+			for _, suffix := range exp.Suffixes {
+				keys = append(keys, ExpectedKey{Key: entry.Key + suffix, Source: entry.Source})
+			}
+		}
+	}
+	return keys, nil
+}
+
+// Missing is a key which a language does not define
+type Missing struct {
+	Key      string
+	Language string
+	// Source is where the key is needed: an entity definition, or the entry in the base language
+	Source entity.Source
+}
+
+// Report is the result of comparing every language against the base language and the expected keys
+type Report struct {
+	Base     string
+	Missing  []Missing
+	Orphaned []Entry
+	// Duplicates are entries ignored since their key was already defined in the same language
+	Duplicates []Duplicate
+}
+
+// Check compares the key set of the base language and each of the languages.
+// A language without any entries is still checked, so every key it should have is reported as missing.
+//
+// A key is missing from a language if it is expected, or if it is defined in the base language.
+// An entry is orphaned if it is defined in a language other than the base, but not in the base language,
+// e.g. a translation of a key which has since been renamed.
+func Check(l *Localization, base string, languages []string, expected []ExpectedKey) *Report {
+	r := &Report{Base: base, Duplicates: l.Duplicates()}
+	for _, language := range withBase(base, languages) {
+		reported := map[string]bool{}
+		for _, exp := range expected {
+			if _, ok := l.Get(exp.Key, language); !ok && !reported[exp.Key] {
+				reported[exp.Key] = true
+				r.Missing = append(r.Missing, Missing{Key: exp.Key, Language: language, Source: exp.Source})
+			}
+		}
+		if language == base {
+			continue
+		}
+
+		for _, key := range l.Keys(base) {
+			if _, ok := l.Get(key, language); !ok && !reported[key] {
+				entry, _ := l.Get(key, base)
+				r.Missing = append(r.Missing, Missing{Key: key, Language: language, Source: entry.Source})
+			}
+		}
+		for _, key := range l.Keys(language) {
+			if _, ok := l.Get(key, base); !ok {
+				entry, _ := l.Get(key, language)
+				r.Orphaned = append(r.Orphaned, entry)
+			}
+		}
+	}
+	return r
+}
+
+// CheckFrom loads the languages and the keys of the expectations from the root, then checks them.
+// The base language is loaded even if it is not one of the languages.
+func CheckFrom(g dirs.Root, base string, languages []string, expectations []Expectation) (*Report, error) {
+	l, err := LoadFrom(g, withBase(base, languages)...)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := ExpectedKeys(g, expectations)
+	if err != nil {
+		return nil, err
+	}
+	return Check(l, base, languages, expected), nil
+}
+
+// withBase returns the base language followed by the other languages, without repeating any
+func withBase(base string, languages []string) []string {
+	all := []string{base}
+	for _, language := range languages {
+		if !slices.Contains(all, language) {
+			all = append(all, language)
+		}
+	}
+	return all
+}
+
+// OK reports whether nothing is missing, orphaned or duplicated
+func (r *Report) OK() bool {
+	return len(r.Missing) == 0 && len(r.Orphaned) == 0 && len(r.Duplicates) == 0
+}
+
+// Write writes one line per problem, each starting with the file and position it concerns
+func (r *Report) Write(w io.Writer) error {
+	for _, m := range r.Missing {
+		if _, err := fmt.Fprintf(w, "%s: missing %s key %s\n", m.Source, m.Language, m.Key); err != nil {
+			return err
+		}
+	}
+	for _, e := range r.Orphaned {
+		if _, err := fmt.Fprintf(w, "%s: orphaned %s key %s is not defined in %s\n", e.Source, e.Language, e.Key, r.Base); err != nil {
+			return err
+		}
+	}
+	for _, d := range r.Duplicates {
+		if _, err := fmt.Fprintf(w, "%s: duplicate %s key %s is already defined at %s\n", d.Ignored.Source, d.Ignored.Language, d.Ignored.Key, d.Kept.Source); err != nil {
+			return err
+		}
+	}
+	return nil
+}
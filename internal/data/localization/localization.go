@@ -0,0 +1,176 @@
+package localization
+
+import (
+	"errors"
+	"path/filepath"
+	"slices"
+	"strings"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+// Languages supported by the game, as used in directory and file names
+const (
+	BrazilianPortuguese = "braz_por"
+	English             = "english"
+	French              = "french"
+	German              = "german"
+	Japanese            = "japanese"
+	Korean              = "korean"
+	Polish              = "polish"
+	Russian             = "russian"
+	SimplifiedChinese   = "simp_chinese"
+	Spanish             = "spanish"
+	Turkish             = "turkish"
+)
+
+// AllLanguages are every language supported by the game, in alphabetical order
+var AllLanguages = []string{
+	BrazilianPortuguese, English, French, German, Japanese, Korean, Polish, Russian, SimplifiedChinese, Spanish, Turkish,
+}
+
+// replaceDir is the name of the subdirectory whose entries override those of every other file
+const replaceDir = "replace"
+
+// Override is an entry replaced by one from a replace/ directory
+type Override struct {
+	Previous, Current Entry
+}
+
+// Duplicate is an entry which was ignored, since the key was already defined outside a replace/ directory
+type Duplicate struct {
+	Kept, Ignored Entry
+}
+
+// Localization is a key to text table for each language.
+//
+// As in the game, the first definition of a key wins, unless the key is defined again in a replace/ directory,
+// whose files are applied after every other file.
+type Localization struct {
+	languages  []string
+	keys       map[string][]string
+	tables     map[string]map[string]Entry
+	overrides  []Override
+	duplicates []Duplicate
+}
+
+func newLocalization() *Localization {
+	return &Localization{keys: map[string][]string{}, tables: map[string]map[string]Entry{}}
+}
+
+// Load loads the languages from the game's localization directory
+func Load(languages ...string) (*Localization, error) {
+	g, err := dirs.DefaultGameInstall()
+	if err != nil {
+		return nil, err
+	}
+	return LoadFrom(g, languages...)
+}
+
+// LoadFrom loads the languages from the localization directory of the root, e.g. LoadFrom(g, English)
+func LoadFrom(g dirs.Root, languages ...string) (*Localization, error) {
+	if len(languages) == 0 {
+		return nil, errors.New("no languages to load")
+	}
+	var srcs []files.FSFile
+	for _, language := range languages {
+		langSrcs, err := g.LocalizationFiles(language)
+		if err != nil {
+			return nil, err
+		}
+		srcs = append(srcs, langSrcs...)
+	}
+	return LoadFiles(srcs)
+}
+
+// LoadFiles reads each file in order, then applies the files in replace/ directories.
+// Each file is added to the table of the language named in its header.
+func LoadFiles[S files.Source](srcs []S) (*Localization, error) {
+	var base, replace []*File
+	for _, src := range srcs {
+		file, err := ReadSource(src)
+		if err != nil {
+			return nil, err
+		}
+		if isReplace(src.Name()) {
+			replace = append(replace, file)
+		} else {
+			base = append(base, file)
+		}
+	}
+
+	l := newLocalization()
+	for _, file := range base {
+		for _, entry := range file.Entries {
+			l.add(entry, false)
+		}
+	}
+	for _, file := range replace {
+		for _, entry := range file.Entries {
+			l.add(entry, true)
+		}
+	}
+	return l, nil
+}
+
+// isReplace reports whether the file is within a replace/ directory, at any depth
+func isReplace(name string) bool {
+	parts := strings.Split(filepath.ToSlash(name), "/")
+	return slices.Contains(parts[:len(parts)-1], replaceDir)
+}
+
+func (l *Localization) add(entry Entry, replace bool) {
+	table, ok := l.tables[entry.Language]
+	if !ok {
+		table = map[string]Entry{}
+		l.tables[entry.Language] = table
+		l.languages = append(l.languages, entry.Language)
+	}
+
+	prev, ok := table[entry.Key]
+	switch {
+	case !ok:
+		l.keys[entry.Language] = append(l.keys[entry.Language], entry.Key)
+	case replace:
+		l.overrides = append(l.overrides, Override{Previous: prev, Current: entry})
+	default:
+		l.duplicates = append(l.duplicates, Duplicate{Kept: prev, Ignored: entry})
+		return
+	}
+	table[entry.Key] = entry
+}
+
+// Get returns the entry for the key in the language
+func (l *Localization) Get(key, language string) (Entry, bool) {
+	entry, ok := l.tables[language][key]
+	return entry, ok
+}
+
+// Name returns the localized text for the key, e.g. Name("small_arms", English) is "Small Arms".
+// The key itself is returned if it has no localization, so it can always be shown in place of a name.
+func (l *Localization) Name(key, language string) string {
+	if entry, ok := l.Get(key, language); ok {
+		return entry.Text
+	}
+	return key
+}
+
+// Languages returns every language loaded, in order of first definition
+func (l *Localization) Languages() []string {
+	return l.languages
+}
+
+// Keys returns every key in the language, in order of first definition
+func (l *Localization) Keys(language string) []string {
+	return l.keys[language]
+}
+
+// Overrides returns every entry replaced by an entry in a replace/ directory, in the order they were applied
+func (l *Localization) Overrides() []Override {
+	return l.overrides
+}
+
+// Duplicates returns every entry ignored because its key was already defined
+func (l *Localization) Duplicates() []Duplicate {
+	return l.duplicates
+}
@@ -0,0 +1,254 @@
+package localization
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	GameDir                      = "testdata"
+	CoverageDir                  = "testdata/coverage"
+	GoodsFile     files.DataFile = "testdata/game/localization/english/goods_l_english.yml"
+	NoBOM         files.DataFile = "testdata/no-bom_l_english.yml"
+	Unquoted      files.DataFile = "testdata/unquoted_l_english.yml"
+	TrailingText  files.DataFile = "testdata/trailing-text_l_english.yml"
+	MissingHeader files.DataFile = "testdata/missing-header_l_english.yml"
+	RenderFile    files.DataFile = "testdata/render_l_english.yml"
+)
+
+func load(t *testing.T, languages ...string) *Localization {
+	l, err := LoadFrom(dirs.OpenGameInstall(GameDir), languages...)
+	if err != nil {
+		t.Fatalf("LoadFrom returned unexpected error: %v", err)
+	}
+	return l
+}
+
+func TestReadSource(t *testing.T) {
+	file, err := ReadSource(GoodsFile)
+	if err != nil {
+		t.Fatalf("ReadSource returned unexpected error: %v", err)
+	}
+	if file.Language != English {
+		t.Errorf("expected: %s, actual: %s", English, file.Language)
+	}
+	if len(file.Entries) != 6 {
+		t.Fatalf("expected %d entries, actual: %d", 6, len(file.Entries))
+	}
+
+	desc := file.Entries[1]
+	if desc.Text != "Rifles and muskets for the #bold army#!" {
+		t.Errorf("unexpected text: %q", desc.Text)
+	}
+	if desc.Source.Line() != 4 || desc.Source.Pos.Col() != 2 {
+		t.Errorf("expected entry at 4:2, actual: %s", desc.Source)
+	}
+	if file.Entries[2].Text != "Ammunition" || file.Entries[2].Version != 0 {
+		t.Errorf("expected an entry without a version, ignoring the comment after it, actual: %+v", file.Entries[2])
+	}
+	if file.Entries[3].Version != 1 {
+		t.Errorf("expected: %d, actual: %d", 1, file.Entries[3].Version)
+	}
+	if file.Entries[4].Text != `The \"Great\" Exhibition` {
+		t.Errorf("escaped quotes should be kept as written, actual: %q", file.Entries[4].Text)
+	}
+	if file.Entries[5].Text != `The "Great" Exhibition` {
+		t.Errorf("text should run to the last quote, actual: %q", file.Entries[5].Text)
+	}
+}
+
+func TestReadSource_errors(t *testing.T) {
+	for _, df := range []files.DataFile{NoBOM, Unquoted, TrailingText, MissingHeader} {
+		if _, err := ReadSource(df); err == nil {
+			t.Errorf("%s: ReadSource did not return an error", df)
+		}
+	}
+}
+
+func TestLoadFrom_Name(t *testing.T) {
+	l := load(t, English, French)
+	if !slices.Equal(l.Languages(), []string{English, French}) {
+		t.Errorf("unexpected languages: %v", l.Languages())
+	}
+
+	tests := []struct{ key, language, expected string }{
+		{"small_arms", English, "Small Arms"},
+		{"small_arms", French, "Armes légères"},
+		{"building_arms_industry", English, "Arms Industry"},
+		{"building_arms_industry", French, "building_arms_industry"},
+		{"undefined_key", English, "undefined_key"},
+	}
+	for _, test := range tests {
+		if actual := l.Name(test.key, test.language); actual != test.expected {
+			t.Errorf("%s (%s): expected: %s, actual: %s", test.key, test.language, test.expected, actual)
+		}
+	}
+}
+
+func TestLoadFrom_replace(t *testing.T) {
+	l := load(t, English)
+	if actual := l.Name("ammunition", English); actual != "Munitions" {
+		t.Errorf("expected replace/ to override; expected: %s, actual: %s", "Munitions", actual)
+	}
+	overrides := l.Overrides()
+	if len(overrides) != 1 || overrides[0].Previous.Text != "Ammunition" {
+		t.Errorf("unexpected overrides: %+v", overrides)
+	}
+}
+
+func TestLoadFrom_firstDefinitionWins(t *testing.T) {
+	l := load(t, English)
+	// buildings_l_english.yml loads before goods_l_english.yml
+	if actual := l.Name("grain", English); actual != "Wheat" {
+		t.Errorf("expected: %s, actual: %s", "Wheat", actual)
+	}
+	duplicates := l.Duplicates()
+	if len(duplicates) != 1 || duplicates[0].Ignored.Text != "Grain" {
+		t.Errorf("unexpected duplicates: %+v", duplicates)
+	}
+	if keys := l.Keys(English); len(keys) != 7 || keys[0] != "building_arms_industry" {
+		t.Errorf("unexpected keys: %v", keys)
+	}
+}
+
+func TestLoadFrom_noLanguages(t *testing.T) {
+	if _, err := LoadFrom(dirs.OpenGameInstall(GameDir)); err == nil {
+		t.Errorf("LoadFrom did not return an error without any languages")
+	}
+}
+
+func renderer(t *testing.T, format Format) *Renderer {
+	l, err := LoadFiles([]files.DataFile{RenderFile})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return NewRenderer(l, English, format)
+}
+
+func TestRender(t *testing.T) {
+	tests := map[Format]map[string]string{
+		Plain: {
+			"small_arms_desc": "Small Arms are made from  iron.\nUsed by [GetPlayer.GetName] armies.",
+			"concept_text":    "See Goods for details",
+			"price":           "Costs $VAL$ for $undefined_key$",
+			"money":           "costs $5 and $6",
+			"nested":          "Very bad",
+			"unclosed":        "Unclosed",
+		},
+		HTML: {
+			"small_arms_desc": `<b>Small Arms</b> are made from <span class="icon" data-icon="iron"></span> iron.<br>Used by <span class="scripted">[GetPlayer.GetName]</span> armies.`,
+			"price":           `Costs <span class="reference">$VAL$</span> for <span class="reference">$undefined_key$</span>`,
+			"escaped":         "5 * 2 &lt; 20 &amp; &#34;quoted&#34;",
+			"nested":          `<span class="N"><b>Very bad</b></span>`,
+			"unclosed":        "<i>Unclosed</i>",
+		},
+		Markdown: {
+			"small_arms_desc": "**Small Arms** are made from :iron: iron.\nUsed by `[GetPlayer.GetName]` armies.",
+			"escaped":         `5 \* 2 < 20 & "quoted"`,
+			"nested":          "**Very bad**",
+		},
+	}
+	for format, cases := range tests {
+		r := renderer(t, format)
+		for key, expected := range cases {
+			actual, err := r.Render(key)
+			if err != nil {
+				t.Errorf("%s %s: Render returned unexpected error: %v", format, key, err)
+			} else if actual != expected {
+				t.Errorf("%s %s: expected: %q, actual: %q", format, key, expected, actual)
+			}
+		}
+	}
+}
+
+func TestRender_errors(t *testing.T) {
+	r := renderer(t, Plain)
+	if _, err := r.Render("loop_a"); err == nil {
+		t.Errorf("Render did not return an error for a reference cycle")
+	}
+	if _, err := r.Render("undefined_key"); err == nil {
+		t.Errorf("Render did not return an error for an undefined key")
+	}
+}
+
+func TestRenderText(t *testing.T) {
+	actual, err := renderer(t, Markdown).RenderText("#bold $goods$#! and $small_arms$")
+	if err != nil {
+		t.Fatalf("RenderText returned unexpected error: %v", err)
+	}
+	if expected := "**Goods** and Small Arms"; actual != expected {
+		t.Errorf("expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestCheckFrom(t *testing.T) {
+	expectations := []Expectation{{Dir: dirs.Goods, Suffixes: []string{"", "_desc"}}, {Dir: dirs.Laws, Suffixes: []string{""}}}
+	r, err := CheckFrom(dirs.OpenGameInstall(CoverageDir), English, []string{French}, expectations)
+	if err != nil {
+		t.Fatalf("CheckFrom returned unexpected error: %v", err)
+	}
+	if r.OK() {
+		t.Errorf("expected problems to be reported")
+	}
+
+	var missing []string
+	for _, m := range r.Missing {
+		missing = append(missing, m.Language+" "+m.Key)
+	}
+	expected := []string{"english grain_desc", "french small_arms_desc", "french grain_desc"}
+	if !slices.Equal(missing, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, missing)
+	}
+	if r.Missing[0].Source.Line() != 2 {
+		t.Errorf("expected the missing key to point at the good's definition, actual: %s", r.Missing[0].Source)
+	}
+
+	if len(r.Orphaned) != 1 || r.Orphaned[0].Key != "renamed_good" {
+		t.Errorf("unexpected orphaned entries: %+v", r.Orphaned)
+	}
+	if len(r.Duplicates) != 1 || r.Duplicates[0].Ignored.Text != "Blé" {
+		t.Errorf("unexpected duplicates: %+v", r.Duplicates)
+	}
+}
+
+func TestReport_Write(t *testing.T) {
+	r, err := CheckFrom(dirs.OpenGameInstall(CoverageDir), English, []string{French}, nil)
+	if err != nil {
+		t.Fatalf("CheckFrom returned unexpected error: %v", err)
+	}
+	var sb strings.Builder
+	if err := r.Write(&sb); err != nil {
+		t.Fatalf("Write returned unexpected error: %v", err)
+	}
+	expected := []string{
+		"game/localization/english/goods_l_english.yml:3:2: missing french key small_arms_desc",
+		"game/localization/french/goods_l_french.yml:5:2: orphaned french key renamed_good is not defined in english",
+		"game/localization/french/goods_l_french.yml:4:2: duplicate french key grain is already defined at game/localization/french/goods_l_french.yml:3:2",
+	}
+	if actual := strings.Split(strings.TrimSpace(sb.String()), "\n"); !slices.Equal(actual, expected) {
+		t.Errorf("expected: %q, actual: %q", expected, actual)
+	}
+}
+
+func TestCheckFrom_languageWithoutFiles(t *testing.T) {
+	s := dirs.NewStack(dirs.OpenGameInstall(CoverageDir).Layer())
+	expectations := []Expectation{{Dir: dirs.Goods, Suffixes: []string{"", "_desc"}}}
+	r, err := CheckFrom(s, English, []string{German}, expectations)
+	if err != nil {
+		t.Fatalf("CheckFrom returned unexpected error: %v", err)
+	}
+
+	var missing []string
+	for _, m := range r.Missing {
+		if m.Language == German {
+			missing = append(missing, m.Key)
+		}
+	}
+	expected := []string{"small_arms", "small_arms_desc", "grain", "grain_desc"}
+	if !slices.Equal(missing, expected) {
+		t.Errorf("expected every key to be missing from german; expected: %v, actual: %v", expected, missing)
+	}
+}
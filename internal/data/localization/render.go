@@ -0,0 +1,291 @@
+package localization
+
+import (
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+)
+
+// Format is the markup a Renderer produces
+type Format int
+
+const (
+	// Plain drops formatting and icons, e.g. for search indexes or tooltips
+	Plain Format = iota
+	HTML
+	Markdown
+)
+
+func (f Format) String() string {
+	switch f {
+	case Plain:
+		return "plain"
+	case HTML:
+		return "html"
+	case Markdown:
+		return "markdown"
+	default:
+		return "unknown"
+	}
+}
+
+// concept matches the data function linking to a game concept, e.g. [Concept('concept_law','$concept_laws$')]
+var concept = regexp.MustCompile(`^Concept\(\s*'([^']*)'\s*,\s*'([^']*)'\s*\)$`)
+
+// Renderer turns localized text into Plain text, HTML, or Markdown:
+//   - $key$ references are replaced by the rendered text of the key, ignoring any |format suffix
+//   - #style text#! formatting becomes bold or italic where the format supports it, and is otherwise dropped
+//   - @icon! becomes an icon placeholder
+//   - [Concept('key','text')] becomes its text, and any other [ ] data function a tagged placeholder,
+//     since it can only be evaluated by the game
+//   - \n becomes a line break
+//
+// A reference to a key which is not defined is kept as a placeholder, like a data function.
+type Renderer struct {
+	loc      *Localization
+	language string
+	format   Format
+}
+
+func NewRenderer(loc *Localization, language string, format Format) *Renderer {
+	return &Renderer{loc: loc, language: language, format: format}
+}
+
+// Render returns the rendered text of the key
+func (r *Renderer) Render(key string) (string, error) {
+	entry, ok := r.loc.Get(key, r.language)
+	if !ok {
+		return "", fmt.Errorf("undefined localization key %q in %s", key, r.language)
+	}
+	var sb strings.Builder
+	if err := r.render(&sb, entry, map[string]bool{}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// RenderText renders text which is not itself an entry, e.g. one built by the caller
+func (r *Renderer) RenderText(text string) (string, error) {
+	var sb strings.Builder
+	if err := r.renderText(&sb, text, Entry{Text: text, Language: r.language}, map[string]bool{}); err != nil {
+		return "", err
+	}
+	return sb.String(), nil
+}
+
+// render writes the entry's text, where visiting holds the keys being rendered so that cycles are found
+func (r *Renderer) render(sb *strings.Builder, entry Entry, visiting map[string]bool) error {
+	if visiting[entry.Key] {
+		return fmt.Errorf("%s: %s: localization refers to itself", entry.Source, entry.Key)
+	}
+	visiting[entry.Key] = true
+	defer delete(visiting, entry.Key)
+	return r.renderText(sb, entry.Text, entry, visiting)
+}
+
+// renderText writes text found in the entry; any unclosed formatting is closed at the end of the text
+func (r *Renderer) renderText(sb *strings.Builder, text string, entry Entry, visiting map[string]bool) error {
+	var styles []string
+	for i := 0; i < len(text); i++ {
+		ch := text[i]
+		rest := text[i+1:]
+		switch {
+		case ch == '\\' && len(rest) > 0:
+			i++
+			if rest[0] == 'n' {
+				sb.WriteString(r.lineBreak())
+			} else {
+				sb.WriteString(r.escape(rest[:1]))
+			}
+		case ch == '$' && isReference(rest):
+			end := strings.IndexByte(rest, '$')
+			key, _, _ := strings.Cut(rest[:end], "|")
+			if err := r.reference(sb, key, visiting); err != nil {
+				return err
+			}
+			i += end + 1
+		case ch == '#' && strings.HasPrefix(rest, "!"):
+			if len(styles) > 0 {
+				sb.WriteString(r.closeStyle(styles[len(styles)-1]))
+				styles = styles[:len(styles)-1]
+			}
+			i++
+		case ch == '#' && len(rest) > 0 && isStyleRune(rest[0]):
+			end := strings.IndexByte(rest, ' ')
+			if end == -1 {
+				end = len(rest)
+			}
+			style, _, _ := strings.Cut(rest[:end], ";")
+			styles = append(styles, style)
+			sb.WriteString(r.openStyle(style))
+			i += end + 1
+		case ch == '@' && isIcon(rest):
+			end := strings.IndexByte(rest, '!')
+			sb.WriteString(r.icon(rest[:end]))
+			i += end + 1
+		case ch == '[' && closingBracket(rest) >= 0:
+			end := closingBracket(rest)
+			if err := r.dataFunction(sb, strings.TrimSpace(rest[:end]), entry, visiting); err != nil {
+				return err
+			}
+			i += end + 1
+		default:
+			sb.WriteString(r.escape(text[i : i+1]))
+		}
+	}
+	for j := len(styles) - 1; j >= 0; j-- {
+		sb.WriteString(r.closeStyle(styles[j]))
+	}
+	return nil
+}
+
+func (r *Renderer) reference(sb *strings.Builder, key string, visiting map[string]bool) error {
+	ref, ok := r.loc.Get(key, r.language)
+	if !ok {
+		sb.WriteString(r.placeholder("$"+key+"$", "reference"))
+		return nil
+	}
+	return r.render(sb, ref, visiting)
+}
+
+func (r *Renderer) dataFunction(sb *strings.Builder, expr string, entry Entry, visiting map[string]bool) error {
+	if m := concept.FindStringSubmatch(expr); m != nil {
+		return r.renderText(sb, m[2], entry, visiting)
+	}
+	sb.WriteString(r.placeholder("["+expr+"]", "scripted"))
+	return nil
+}
+
+// isReference reports whether rest, which follows a '$', is a key ended by '$', so that prices such as $5 are kept as text
+func isReference(rest string) bool {
+	end := strings.IndexByte(rest, '$')
+	if end <= 0 {
+		return false
+	}
+	key, _, _ := strings.Cut(rest[:end], "|")
+	return key != "" && isName(key, ".")
+}
+
+// isStyleRune reports whether the rune can start a style name, so that a lone '#' is kept as text
+func isStyleRune(ch byte) bool {
+	return ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z'
+}
+
+// isIcon reports whether rest, which follows an '@', is an icon name ended by '!'
+func isIcon(rest string) bool {
+	end := strings.IndexByte(rest, '!')
+	if end <= 0 {
+		return false
+	}
+	return isName(rest[:end], "")
+}
+
+// isName reports whether s only has letters, digits, underscores, and any of the extra runes
+func isName(s, extra string) bool {
+	for _, ch := range s {
+		if !(ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z' || ch >= '0' && ch <= '9' || ch == '_' || strings.ContainsRune(extra, ch)) {
+			return false
+		}
+	}
+	return true
+}
+
+// closingBracket returns the index of the ']' closing a data function, allowing for nested brackets, or -1
+func closingBracket(rest string) int {
+	depth := 0
+	for i := 0; i < len(rest); i++ {
+		switch rest[i] {
+		case '[':
+			depth++
+		case ']':
+			if depth == 0 {
+				return i
+			}
+			depth--
+		}
+	}
+	return -1
+}
+
+func (r *Renderer) escape(text string) string {
+	switch r.format {
+	case HTML:
+		return html.EscapeString(text)
+	case Markdown:
+		if strings.ContainsAny(text, "\\*_`[]") {
+			return "\\" + text
+		}
+	}
+	return text
+}
+
+func (r *Renderer) lineBreak() string {
+	if r.format == HTML {
+		return "<br>"
+	}
+	return "\n"
+}
+
+func (r *Renderer) openStyle(style string) string {
+	switch r.format {
+	case HTML:
+		switch style {
+		case "b", "bold":
+			return "<b>"
+		case "i", "italic":
+			return "<i>"
+		}
+		return fmt.Sprintf(`<span class="%s">`, html.EscapeString(style))
+	case Markdown:
+		return markdownStyle(style)
+	}
+	return ""
+}
+
+func (r *Renderer) closeStyle(style string) string {
+	switch r.format {
+	case HTML:
+		switch style {
+		case "b", "bold":
+			return "</b>"
+		case "i", "italic":
+			return "</i>"
+		}
+		return "</span>"
+	case Markdown:
+		return markdownStyle(style)
+	}
+	return ""
+}
+
+func markdownStyle(style string) string {
+	switch style {
+	case "b", "bold":
+		return "**"
+	case "i", "italic":
+		return "*"
+	}
+	return ""
+}
+
+func (r *Renderer) icon(name string) string {
+	switch r.format {
+	case HTML:
+		return fmt.Sprintf(`<span class="icon" data-icon="%s"></span>`, html.EscapeString(name))
+	case Markdown:
+		return ":" + name + ":"
+	}
+	return ""
+}
+
+// placeholder marks text which could not be resolved, so it can be found in the output
+func (r *Renderer) placeholder(text, kind string) string {
+	switch r.format {
+	case HTML:
+		return fmt.Sprintf(`<span class="%s">%s</span>`, kind, html.EscapeString(text))
+	case Markdown:
+		return "`" + text + "`"
+	}
+	return text
+}
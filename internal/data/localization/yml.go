@@ -0,0 +1,134 @@
+package localization
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"unicode"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/files"
+)
+
+// Entry is a single localized string, e.g. ` small_arms:0 "Small Arms"`.
+// The Text is everything between the first and last quote, as written, so it may still contain
+// formatting such as #bold ...#!, $KEY$ references, [ ] scripted text and escapes such as \n.
+type Entry struct {
+	Key string
+	// Version is the number after the colon, which is optional and unused by the game
+	Version  int
+	Text     string
+	Language string
+	Source   entity.Source
+}
+
+// File is a single localization file, e.g. localization/english/goods_l_english.yml
+type File struct {
+	Name     string
+	Language string
+	Entries  []Entry
+}
+
+// ReadSource reads a localization file.
+//
+// The game only reads localization saved as UTF-8 with a byte order mark, so a file without one is an error.
+// The first line which is not blank or a comment must name the language, e.g. `l_english:`.
+func ReadSource(src files.Source) (*File, error) {
+	rdr, err := src.NewReader(files.SkipBOM(), files.NormalizeNewlines())
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rdr.Close() }()
+
+	if !rdr.HasBOM() {
+		return nil, fmt.Errorf("%s: localization files must be UTF-8 with a byte order mark", src.Name())
+	}
+
+	file := &File{Name: src.Name()}
+	for {
+		text, start, err := readLine(rdr)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+		if text != "" && text[0] != '#' {
+			if lineErr := file.addLine(text, start, src.Origin()); lineErr != nil {
+				return nil, lineErr
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+	}
+	if file.Language == "" {
+		return nil, fmt.Errorf("%s: missing language header, e.g. l_english:", src.Name())
+	}
+	return file, nil
+}
+
+// readLine returns the next line without surrounding whitespace, and the position of its first rune
+func readLine(rdr *files.Reader) (string, files.Position, error) {
+	var sb strings.Builder
+	var start files.Position
+	for {
+		ch, err := rdr.Next()
+		if err != nil {
+			return strings.TrimRightFunc(sb.String(), unicode.IsSpace), start, err
+		}
+		if ch == '\n' {
+			return strings.TrimRightFunc(sb.String(), unicode.IsSpace), start, nil
+		}
+		if sb.Len() == 0 {
+			if unicode.IsSpace(ch) {
+				continue
+			}
+			start = rdr.Position()
+		}
+		sb.WriteRune(ch)
+	}
+}
+
+func (f *File) addLine(text string, start files.Position, origin string) error {
+	src := entity.Source{File: f.Name, Pos: start, Origin: origin}
+	if f.Language == "" {
+		header, _, _ := strings.Cut(text, "#")
+		header = strings.TrimSpace(header)
+		if !strings.HasPrefix(header, "l_") || !strings.HasSuffix(header, ":") {
+			return fmt.Errorf("%s: expected a language header, e.g. l_english:", src)
+		}
+		f.Language = strings.TrimSuffix(strings.TrimPrefix(header, "l_"), ":")
+		return nil
+	}
+
+	key, rest, ok := strings.Cut(text, ":")
+	if !ok || key == "" || strings.ContainsFunc(key, unicode.IsSpace) {
+		return fmt.Errorf("%s: expected an entry of the form key:0 \"text\"", src)
+	}
+
+	version := 0
+	digits := strings.IndexFunc(rest, func(r rune) bool { return r < '0' || r > '9' })
+	if digits == -1 {
+		digits = len(rest)
+	}
+	if digits > 0 {
+		version, _ = strconv.Atoi(rest[:digits])
+	}
+
+	quoted := strings.TrimLeftFunc(rest[digits:], unicode.IsSpace)
+	// as in the game, the text runs to the last quote on the line, so it may contain unescaped quotes
+	end := strings.LastIndexByte(quoted, '"')
+	if !strings.HasPrefix(quoted, `"`) || end == 0 {
+		return fmt.Errorf("%s: %s: expected quoted text", src, key)
+	}
+	if after := strings.TrimSpace(quoted[end+1:]); after != "" && after[0] != '#' {
+		return fmt.Errorf("%s: %s: unexpected %q after quoted text", src, key, after)
+	}
+
+	f.Entries = append(f.Entries, Entry{
+		Key:      key,
+		Version:  version,
+		Text:     quoted[1:end],
+		Language: f.Language,
+		Source:   src,
+	})
+	return nil
+}
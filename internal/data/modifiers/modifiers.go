@@ -0,0 +1,120 @@
+package modifiers
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Modifier is a single `name = value` entry in a modifier block.
+// yes and no are stored as 1 and 0. Values which are not numbers, such as script value references, are kept in Ref.
+type Modifier struct {
+	Name  string
+	Value float64
+	Ref   string
+	Pos   files.Position
+}
+
+// Modifiers are kept in the order they are defined
+type Modifiers []Modifier
+
+func (m *Modifiers) UnmarshalPDX(val parser.Value) error {
+	blk, ok := val.(*parser.Block)
+	if !ok {
+		return fmt.Errorf("%d:%d: expected a block of modifiers", val.Start().Line(), val.Start().Col())
+	}
+
+	*m = nil
+	for _, item := range blk.Items {
+		f, ok := item.(*parser.Field)
+		if !ok {
+			return fmt.Errorf("%d:%d: expected modifier = value", item.Start().Line(), item.Start().Col())
+		}
+		s, ok := f.Value.(*parser.Scalar)
+		if !ok {
+			return fmt.Errorf("%d:%d: modifier %q must have a single value", f.Start().Line(), f.Start().Col(), f.Name())
+		}
+
+		mod := Modifier{Name: f.Name(), Pos: f.Start()}
+		switch text := s.Text(); text {
+		case "yes":
+			mod.Value = 1
+		case "no":
+			mod.Value = 0
+		default:
+			n, err := strconv.ParseFloat(text, 64)
+			if err != nil {
+				mod.Ref = text
+			}
+			mod.Value = n
+		}
+		*m = append(*m, mod)
+	}
+	return nil
+}
+
+// Get returns the value of the last modifier with the name
+func (m Modifiers) Get(name string) (float64, bool) {
+	for i := len(m) - 1; i >= 0; i-- {
+		if m[i].Name == name {
+			return m[i].Value, true
+		}
+	}
+	return 0, false
+}
+
+type Direction string
+
+const (
+	Input  Direction = "input"
+	Output Direction = "output"
+)
+
+// Op is how a modifier is applied, taken from its name's suffix
+type Op string
+
+const (
+	Add  Op = "add"
+	Mult Op = "mult"
+)
+
+// cutOp splits the `_add` or `_mult` suffix from a modifier name
+func cutOp(name string) (string, Op, bool) {
+	if rest, ok := strings.CutSuffix(name, "_add"); ok {
+		return rest, Add, true
+	}
+	if rest, ok := strings.CutSuffix(name, "_mult"); ok {
+		return rest, Mult, true
+	}
+	return name, "", false
+}
+
+// ParseGoods splits a goods modifier name such as `goods_input_small_arms_add` into its good, direction, and op
+func ParseGoods(name string) (good string, dir Direction, op Op, ok bool) {
+	rest, op, ok := cutOp(name)
+	if !ok {
+		return "", "", "", false
+	}
+	if good, ok = strings.CutPrefix(rest, "goods_input_"); ok && good != "" {
+		return good, Input, op, true
+	}
+	if good, ok = strings.CutPrefix(rest, "goods_output_"); ok && good != "" {
+		return good, Output, op, true
+	}
+	return "", "", "", false
+}
+
+// ParseEmployment splits an employment modifier name such as `building_employment_laborers_add` into its pop type and op
+func ParseEmployment(name string) (popType string, op Op, ok bool) {
+	rest, op, ok := cutOp(name)
+	if !ok {
+		return "", "", false
+	}
+	popType, ok = strings.CutPrefix(rest, "building_employment_")
+	if !ok || popType == "" {
+		return "", "", false
+	}
+	return popType, op, true
+}
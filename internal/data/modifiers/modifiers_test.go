@@ -0,0 +1,96 @@
+package modifiers
+
+import (
+	"testing"
+	"vic3-data-reader/internal/read/decode"
+	"vic3-data-reader/internal/read/files"
+)
+
+const SmokeSample files.DataFile = "testdata/modifiers.txt"
+
+func load(t *testing.T) Modifiers {
+	var v struct {
+		Modifier Modifiers `pdx:"modifier"`
+	}
+	err := decode.UnmarshalFile(SmokeSample, &v)
+	if err != nil {
+		t.Fatalf("UnmarshalFile returned unexpected error: %v", err)
+	}
+	return v.Modifier
+}
+
+func TestUnmarshalPDX(t *testing.T) {
+	mods := load(t)
+	if len(mods) != 5 {
+		t.Fatalf("expected %d modifiers, actual: %d", 5, len(mods))
+	}
+	if mods[0].Name != "country_prestige_add" || mods[0].Value != 10 {
+		t.Errorf("unexpected first modifier: %v", mods[0])
+	}
+	if mods[1].Value != 1 {
+		t.Errorf("yes should be stored as 1, actual: %v", mods[1].Value)
+	}
+	if mods[2].Value != -0.25 {
+		t.Errorf("expected: %v, actual: %v", -0.25, mods[2].Value)
+	}
+	if mods[3].Ref != "country_companies_value" {
+		t.Errorf("expected: %s, actual: %s", "country_companies_value", mods[3].Ref)
+	}
+	if mods[1].Pos.Line() != mods[0].Pos.Line()+1 {
+		t.Errorf("expected modifiers on consecutive lines; actual: %d and %d", mods[0].Pos.Line(), mods[1].Pos.Line())
+	}
+}
+
+func TestGet_returnsLast(t *testing.T) {
+	v, ok := load(t).Get("country_prestige_add")
+	if !ok || v != 20 {
+		t.Errorf("expected: %v, actual: %v", 20, v)
+	}
+	_, ok = load(t).Get("missing")
+	if ok {
+		t.Errorf("Get returned ok for missing modifier")
+	}
+}
+
+func TestParseGoods(t *testing.T) {
+	tests := []struct {
+		name string
+		good string
+		dir  Direction
+		op   Op
+		ok   bool
+	}{
+		{"goods_input_grain_add", "grain", Input, Add, true},
+		{"goods_output_small_arms_add", "small_arms", Output, Add, true},
+		{"goods_output_luxury_clothes_mult", "luxury_clothes", Output, Mult, true},
+		{"goods_input__add", "", "", "", false},
+		{"building_employment_laborers_add", "", "", "", false},
+		{"goods_input_grain", "", "", "", false},
+	}
+	for _, tt := range tests {
+		good, dir, op, ok := ParseGoods(tt.name)
+		if good != tt.good || dir != tt.dir || op != tt.op || ok != tt.ok {
+			t.Errorf("%s: expected: (%s, %s, %s, %v), actual: (%s, %s, %s, %v)", tt.name, tt.good, tt.dir, tt.op, tt.ok, good, dir, op, ok)
+		}
+	}
+}
+
+func TestParseEmployment(t *testing.T) {
+	tests := []struct {
+		name    string
+		popType string
+		op      Op
+		ok      bool
+	}{
+		{"building_employment_laborers_add", "laborers", Add, true},
+		{"building_employment_machinists_mult", "machinists", Mult, true},
+		{"building_laborers_mortality_mult", "", "", false},
+		{"goods_input_grain_add", "", "", false},
+	}
+	for _, tt := range tests {
+		popType, op, ok := ParseEmployment(tt.name)
+		if popType != tt.popType || op != tt.op || ok != tt.ok {
+			t.Errorf("%s: expected: (%s, %s, %v), actual: (%s, %s, %v)", tt.name, tt.popType, tt.op, tt.ok, popType, op, ok)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package popneeds
+
+import (
+	"fmt"
+	"vic3-data-reader/internal/data/goods"
+)
+
+// Baskets works out how much of each good pops of each wealth level consume.
+//
+// The amount of each need is split between the need's goods by their weights, within each entry's
+// min_supply_share and max_supply_share. This is the split when every good has its base price;
+// in game, cheaper goods take a bigger share.
+type Baskets struct {
+	needs    *PopNeeds
+	packages map[int]BuyPackage
+	goods    *goods.Goods
+	shares   map[string]map[string]float64
+}
+
+// NewBaskets checks that every good and need referenced is defined, and that every package has a wealth level
+func NewBaskets(needs *PopNeeds, packages *BuyPackages, gs *goods.Goods) (*Baskets, error) {
+	b := &Baskets{
+		needs:    needs,
+		packages: map[int]BuyPackage{},
+		goods:    gs,
+		shares:   map[string]map[string]float64{},
+	}
+
+	for _, entry := range needs.Entries() {
+		need := entry.Value
+		if _, ok := gs.Get(need.Default); need.Default != "" && !ok {
+			return nil, fmt.Errorf("%s: pop need %q has undefined default good %q", entry.Source, entry.Key, need.Default)
+		}
+		for _, e := range need.Entries {
+			if _, ok := gs.Get(e.Goods); !ok {
+				return nil, fmt.Errorf("%s: pop need %q has undefined good %q", entry.Source, entry.Key, e.Goods)
+			}
+		}
+		b.shares[entry.Key] = Shares(need)
+	}
+
+	for _, entry := range packages.Entries() {
+		wealth, ok := entry.Value.Wealth()
+		if !ok {
+			return nil, fmt.Errorf("%s: buy package %q is not of the form wealth_<n>", entry.Source, entry.Key)
+		}
+		for need := range entry.Value.Goods {
+			if _, ok := needs.Get(need); !ok {
+				return nil, fmt.Errorf("%s: buy package %q has undefined pop need %q", entry.Source, entry.Key, need)
+			}
+		}
+		b.packages[wealth] = entry.Value
+	}
+	return b, nil
+}
+
+// Basket returns the amount of each good bought by a pop of the wealth level.
+// The result is empty if no package is defined for the wealth level.
+func (b *Baskets) Basket(wealth int) map[string]float64 {
+	basket := map[string]float64{}
+	pkg, ok := b.packages[wealth]
+	if !ok {
+		return basket
+	}
+	for need, amount := range pkg.Goods {
+		for good, share := range b.shares[need] {
+			basket[good] += amount * share
+		}
+	}
+	return basket
+}
+
+// CategoryBasket returns the amount of goods of each category bought by a pop of the wealth level
+func (b *Baskets) CategoryBasket(wealth int) map[goods.Category]float64 {
+	basket := map[goods.Category]float64{}
+	for key, amount := range b.Basket(wealth) {
+		good, _ := b.goods.Get(key)
+		basket[good.Category] += amount
+	}
+	return basket
+}
+
+// Needs returns the amount of each need bought by a pop of the wealth level
+func (b *Baskets) Needs(wealth int) map[string]float64 {
+	needs := map[string]float64{}
+	for need, amount := range b.packages[wealth].Goods {
+		needs[need] = amount
+	}
+	return needs
+}
+
+// Shares splits a need between its goods in proportion to their weights.
+// Entries which would supply more than their max supply share are fixed at it, and the rest of the need
+// is split again between the others; only then are entries below their min supply share raised to it,
+// and the rest split again, so capping one entry can still lift another above its min.
+//
+// Any share left over, because every entry is fixed at a bound or none has a weight, is supplied by the default good.
+// Without a default good the left over share is not bought at all.
+func Shares(need PopNeed) map[string]float64 {
+	shares := map[string]float64{}
+	fixed := make([]bool, len(need.Entries))
+	remaining := 1.0
+
+	for {
+		total := 0.0
+		for i, e := range need.Entries {
+			if !fixed[i] {
+				total += e.Weight
+			}
+		}
+		if total == 0 {
+			break
+		}
+
+		share := func(e Entry) float64 {
+			return remaining * e.Weight / total
+		}
+		// every entry over its max is fixed at once, since capping them only raises the shares of the rest;
+		// likewise for entries under their min, which only lowers the rest
+		if fixedShare, ok := fixBounds(need.Entries, fixed, shares, func(e Entry) (float64, bool) {
+			return e.maxShare(), share(e) > e.maxShare()
+		}); ok {
+			remaining -= fixedShare
+			continue
+		}
+		if fixedShare, ok := fixBounds(need.Entries, fixed, shares, func(e Entry) (float64, bool) {
+			return e.MinSupplyShare, share(e) < e.MinSupplyShare
+		}); ok {
+			remaining -= fixedShare
+			continue
+		}
+
+		for i, e := range need.Entries {
+			if !fixed[i] {
+				shares[e.Goods] = share(e)
+			}
+		}
+		remaining = 0
+		break
+	}
+
+	if remaining > 1e-9 && need.Default != "" {
+		shares[need.Default] += remaining
+	}
+	return shares
+}
+
+// fixBounds fixes every entry which is not yet fixed and is outside the bound, returning the total share fixed
+func fixBounds(entries []Entry, fixed []bool, shares map[string]float64, outside func(Entry) (float64, bool)) (float64, bool) {
+	total, clamped := 0.0, false
+	for i, e := range entries {
+		if fixed[i] {
+			continue
+		}
+		if bound, ok := outside(e); ok {
+			shares[e.Goods] = bound
+			fixed[i] = true
+			total += bound
+			clamped = true
+		}
+	}
+	return total, clamped
+}
@@ -0,0 +1,87 @@
+package popneeds
+
+import (
+	"strconv"
+	"strings"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+// PopNeed is a single entry in common/pop_needs.
+// A need can be satisfied by any of its entries' goods, which substitute for each other.
+type PopNeed struct {
+	Key     string  `pdx:",key"`
+	Default string  `pdx:"default"`
+	Entries []Entry `pdx:"entry"`
+}
+
+// Entry is one of the goods which can satisfy a need
+type Entry struct {
+	Goods          string   `pdx:"goods"`
+	Weight         float64  `pdx:"weight"`
+	MaxSupplyShare *float64 `pdx:"max_supply_share"`
+	MinSupplyShare float64  `pdx:"min_supply_share"`
+}
+
+// maxShare is the largest share of the need the entry may supply, which defaults to all of it
+func (e Entry) maxShare() float64 {
+	if e.MaxSupplyShare == nil {
+		return 1
+	}
+	return *e.MaxSupplyShare
+}
+
+// BuyPackage is a single entry in common/buy_packages, e.g. `wealth_5 = { ... }`.
+// Goods maps each need to the amount a pop of that wealth buys.
+type BuyPackage struct {
+	Key               string             `pdx:",key"`
+	PoliticalStrength float64            `pdx:"political_strength"`
+	Goods             map[string]float64 `pdx:"goods"`
+}
+
+// wealthPrefix is the prefix of every buy package key
+const wealthPrefix = "wealth_"
+
+// Wealth is the wealth level the package is for, taken from its key
+func (b BuyPackage) Wealth() (int, bool) {
+	if !strings.HasPrefix(b.Key, wealthPrefix) {
+		return 0, false
+	}
+	wealth, err := strconv.Atoi(strings.TrimPrefix(b.Key, wealthPrefix))
+	return wealth, err == nil
+}
+
+// PopNeeds are kept in the order the game defines them
+type PopNeeds = entity.Collection[PopNeed]
+
+// BuyPackages are kept in the order the game defines them, which is also increasing wealth
+type BuyPackages = entity.Collection[BuyPackage]
+
+// Load loads every need from the game's pop needs directory
+func Load() (*PopNeeds, error) {
+	return entity.LoadDir[PopNeed](dirs.PopNeeds)
+}
+
+// LoadFrom loads every need from the pop needs directory of the root
+func LoadFrom(g dirs.Root) (*PopNeeds, error) {
+	return entity.LoadInstall[PopNeed](g, dirs.PopNeeds)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*PopNeeds, error) {
+	return entity.LoadFiles[PopNeed](srcs)
+}
+
+// LoadBuyPackages loads every package from the game's buy packages directory
+func LoadBuyPackages() (*BuyPackages, error) {
+	return entity.LoadDir[BuyPackage](dirs.BuyPackages)
+}
+
+// LoadBuyPackagesFrom loads every package from the buy packages directory of the root
+func LoadBuyPackagesFrom(g dirs.Root) (*BuyPackages, error) {
+	return entity.LoadInstall[BuyPackage](g, dirs.BuyPackages)
+}
+
+func LoadBuyPackagesFiles[S files.Source](srcs []S) (*BuyPackages, error) {
+	return entity.LoadFiles[BuyPackage](srcs)
+}
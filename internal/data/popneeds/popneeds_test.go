@@ -0,0 +1,169 @@
+package popneeds
+
+import (
+	"math"
+	"testing"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/data/goods"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	NeedsSample    files.DataFile = "testdata/00_pop_needs.txt"
+	PackagesSample files.DataFile = "testdata/00_buy_packages.txt"
+	GoodsSample    files.DataFile = "testdata/00_goods.txt"
+	Undefined      files.DataFile = "testdata/undefined.txt"
+)
+
+func loadNeeds(t *testing.T) *PopNeeds {
+	needs, err := LoadFiles([]files.DataFile{NeedsSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return needs
+}
+
+func loadGoods(t *testing.T) *goods.Goods {
+	gs, err := goods.LoadFiles([]files.DataFile{GoodsSample})
+	if err != nil {
+		t.Fatalf("goods.LoadFiles returned unexpected error: %v", err)
+	}
+	return gs
+}
+
+func loadBaskets(t *testing.T) *Baskets {
+	packages, err := LoadBuyPackagesFiles([]files.DataFile{PackagesSample})
+	if err != nil {
+		t.Fatalf("LoadBuyPackagesFiles returned unexpected error: %v", err)
+	}
+	b, err := NewBaskets(loadNeeds(t), packages, loadGoods(t))
+	if err != nil {
+		t.Fatalf("NewBaskets returned unexpected error: %v", err)
+	}
+	return b
+}
+
+func assertAmounts[K comparable](t *testing.T, expected, actual map[K]float64) {
+	t.Helper()
+	if len(actual) != len(expected) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+	for k, v := range expected {
+		if math.Abs(actual[k]-v) > 1e-9 {
+			t.Errorf("%v: expected: %v, actual: %v", k, v, actual[k])
+		}
+	}
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	needs := loadNeeds(t)
+	if needs.Len() != 4 {
+		t.Fatalf("expected %d needs, actual: %d", 4, needs.Len())
+	}
+	food, _ := needs.Get("popneed_basic_food")
+	if food.Default != "grain" || len(food.Entries) != 3 {
+		t.Errorf("unexpected popneed_basic_food: %+v", food)
+	}
+	if meat := food.Entries[2]; meat.Goods != "meat" || meat.Weight != 2 || *meat.MaxSupplyShare != 0.7 {
+		t.Errorf("unexpected meat entry: %+v", meat)
+	}
+}
+
+func TestLoadBuyPackagesFiles_wealth(t *testing.T) {
+	packages, err := LoadBuyPackagesFiles([]files.DataFile{PackagesSample})
+	if err != nil {
+		t.Fatalf("LoadBuyPackagesFiles returned unexpected error: %v", err)
+	}
+	pkg, _ := packages.Get("wealth_10")
+	if wealth, ok := pkg.Wealth(); !ok || wealth != 10 {
+		t.Errorf("expected wealth: %d, actual: %d", 10, wealth)
+	}
+	if pkg.Goods["popneed_luxury_drinks"] != 4 {
+		t.Errorf("expected: %v, actual: %v", 4, pkg.Goods["popneed_luxury_drinks"])
+	}
+	if _, ok := (BuyPackage{Key: "poor"}).Wealth(); ok {
+		t.Errorf("Wealth returned ok for a key without a wealth level")
+	}
+}
+
+func TestShares(t *testing.T) {
+	needs := loadNeeds(t)
+	tests := map[string]map[string]float64{
+		"popneed_basic_food":      {"grain": 0.25, "fish": 0.25, "meat": 0.5},
+		"popneed_simple_clothing": {"clothes": 0.8, "fabric": 0.2},
+		"popneed_luxury_drinks":   {"liquor": 0.5, "wine": 0.5},
+		"popneed_services":        {"services": 1},
+	}
+	for key, expected := range tests {
+		need, _ := needs.Get(key)
+		assertAmounts(t, expected, Shares(need))
+	}
+}
+
+func TestShares_minSupplyShare(t *testing.T) {
+	need := PopNeed{Entries: []Entry{
+		{Goods: "a", Weight: 9},
+		{Goods: "b", Weight: 1, MinSupplyShare: 0.4},
+	}}
+	assertAmounts(t, map[string]float64{"a": 0.6, "b": 0.4}, Shares(need))
+}
+
+func TestShares_maxBeforeMin(t *testing.T) {
+	half := 0.5
+	need := PopNeed{Entries: []Entry{
+		{Goods: "a", Weight: 8, MaxSupplyShare: &half},
+		{Goods: "b", Weight: 1, MinSupplyShare: 0.2},
+		{Goods: "c", Weight: 1},
+	}}
+	// once a is capped, b's share is above its min, so b and c split the rest evenly
+	assertAmounts(t, map[string]float64{"a": 0.5, "b": 0.25, "c": 0.25}, Shares(need))
+}
+
+func TestShares_leftOverGoesToDefault(t *testing.T) {
+	half := 0.5
+	need := PopNeed{Default: "d", Entries: []Entry{{Goods: "a", Weight: 1, MaxSupplyShare: &half}}}
+	assertAmounts(t, map[string]float64{"a": 0.5, "d": 0.5}, Shares(need))
+
+	need.Default = ""
+	assertAmounts(t, map[string]float64{"a": 0.5}, Shares(need))
+}
+
+func TestBasket(t *testing.T) {
+	b := loadBaskets(t)
+	assertAmounts(t, map[string]float64{
+		"grain": 2.5, "fish": 2.5, "meat": 5, "clothes": 4, "fabric": 1,
+	}, b.Basket(1))
+	assertAmounts(t, map[string]float64{
+		"grain": 5, "fish": 5, "meat": 10, "clothes": 8, "fabric": 2, "liquor": 2, "wine": 2, "services": 2,
+	}, b.Basket(10))
+
+	if basket := b.Basket(99); len(basket) != 0 {
+		t.Errorf("expected an empty basket for an undefined wealth level, actual: %v", basket)
+	}
+}
+
+func TestCategoryBasket(t *testing.T) {
+	assertAmounts(t, map[goods.Category]float64{
+		goods.Staple: 32, goods.Industrial: 2, goods.Luxury: 2,
+	}, loadBaskets(t).CategoryBasket(10))
+}
+
+func TestNewBaskets_undefinedNeed(t *testing.T) {
+	packages, err := LoadBuyPackagesFiles([]files.DataFile{Undefined})
+	if err != nil {
+		t.Fatalf("LoadBuyPackagesFiles returned unexpected error: %v", err)
+	}
+	if _, err := NewBaskets(loadNeeds(t), packages, loadGoods(t)); err == nil {
+		t.Errorf("NewBaskets did not return an error for an undefined pop need")
+	}
+}
+
+func TestNewBaskets_undefinedGood(t *testing.T) {
+	packages, err := LoadBuyPackagesFiles([]files.DataFile{PackagesSample})
+	if err != nil {
+		t.Fatalf("LoadBuyPackagesFiles returned unexpected error: %v", err)
+	}
+	if _, err := NewBaskets(loadNeeds(t), packages, entity.NewCollection[goods.Good]()); err == nil {
+		t.Errorf("NewBaskets did not return an error for undefined goods")
+	}
+}
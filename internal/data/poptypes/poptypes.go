@@ -0,0 +1,77 @@
+package poptypes
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+type Strata string
+
+const (
+	Lower  Strata = "lower"
+	Middle Strata = "middle"
+	Upper  Strata = "upper"
+)
+
+// PopType is a single entry in common/pop_types
+type PopType struct {
+	Key                               string        `pdx:",key"`
+	Texture                           string        `pdx:"texture"`
+	Color                             parser.Value  `pdx:"color"`
+	Strata                            Strata        `pdx:"strata"`
+	StartQualityOfLife                float64       `pdx:"start_quality_of_life"`
+	WageWeight                        float64       `pdx:"wage_weight"`
+	DependentWage                     float64       `pdx:"dependent_wage"`
+	PaidPrivateWage                   bool          `pdx:"paid_private_wage"`
+	ConsumptionMult                   *float64      `pdx:"consumption_mult"`
+	LiteracyTarget                    float64       `pdx:"literacy_target"`
+	EducationAccess                   float64       `pdx:"education_access"`
+	WorkingAdultRatio                 float64       `pdx:"working_adult_ratio"`
+	PoliticalEngagementBase           float64       `pdx:"political_engagement_base"`
+	PoliticalEngagementLiteracyFactor float64       `pdx:"political_engagement_literacy_factor"`
+	Unemployment                      bool          `pdx:"unemployment"`
+	CanAlwaysHire                     bool          `pdx:"can_always_hire"`
+	SubsistenceIncome                 bool          `pdx:"subsistence_income"`
+	IgnoresEmploymentProportionality  bool          `pdx:"ignores_employment_proportionality"`
+	Military                          bool          `pdx:"military"`
+	IsSlave                           bool          `pdx:"is_slave"`
+	Qualifications                    *parser.Block `pdx:"qualifications"`
+}
+
+// Consumption is the multiplier applied to the pop type's needs, which defaults to 1
+func (p PopType) Consumption() float64 {
+	if p.ConsumptionMult == nil {
+		return 1
+	}
+	return *p.ConsumptionMult
+}
+
+// PopTypes are kept in the order the game defines them
+type PopTypes = entity.Collection[PopType]
+
+// Load loads every pop type from the game's pop types directory
+func Load() (*PopTypes, error) {
+	return entity.LoadDir[PopType](dirs.PopTypes)
+}
+
+// LoadFrom loads every pop type from the pop types directory of the root
+func LoadFrom(g dirs.Root) (*PopTypes, error) {
+	return entity.LoadInstall[PopType](g, dirs.PopTypes)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*PopTypes, error) {
+	return entity.LoadFiles[PopType](srcs)
+}
+
+// ByStrata returns the pop types in the strata, in definition order
+func ByStrata(popTypes *PopTypes, strata Strata) []PopType {
+	var matches []PopType
+	for _, p := range popTypes.Values() {
+		if p.Strata == strata {
+			matches = append(matches, p)
+		}
+	}
+	return matches
+}
@@ -0,0 +1,69 @@
+package poptypes
+
+import (
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	SmokeSample      files.DataFile = "testdata/00_pop_types.txt"
+	ExpectedPopTypes                = 5
+)
+
+func load(t *testing.T) *PopTypes {
+	popTypes, err := LoadFiles([]files.DataFile{SmokeSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return popTypes
+}
+
+func TestLoadFiles_count(t *testing.T) {
+	popTypes := load(t)
+	if popTypes.Len() != ExpectedPopTypes {
+		t.Errorf("expected %d pop types, actual: %d", ExpectedPopTypes, popTypes.Len())
+	}
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	aristocrats, ok := load(t).Get("aristocrats")
+	if !ok {
+		t.Fatalf("missing pop type %q", "aristocrats")
+	}
+	if aristocrats.Strata != Upper {
+		t.Errorf("expected: %s, actual: %s", Upper, aristocrats.Strata)
+	}
+	if aristocrats.WageWeight != 5 {
+		t.Errorf("expected: %v, actual: %v", 5, aristocrats.WageWeight)
+	}
+	if !aristocrats.PaidPrivateWage {
+		t.Errorf("expected aristocrats to be paid a private wage")
+	}
+	if aristocrats.Qualifications == nil {
+		t.Errorf("expected qualifications to be kept as a raw block")
+	}
+}
+
+func TestConsumption(t *testing.T) {
+	popTypes := load(t)
+	expected := map[string]float64{"aristocrats": 2, "clerks": 1, "peasants": 0.1}
+	for key, mult := range expected {
+		p, _ := popTypes.Get(key)
+		if p.Consumption() != mult {
+			t.Errorf("%s: expected: %v, actual: %v", key, mult, p.Consumption())
+		}
+	}
+}
+
+func TestByStrata(t *testing.T) {
+	lower := ByStrata(load(t), Lower)
+	expected := []string{"laborers", "peasants", "soldiers"}
+	if len(lower) != len(expected) {
+		t.Fatalf("expected %d lower strata pop types, actual: %d", len(expected), len(lower))
+	}
+	for i, key := range expected {
+		if lower[i].Key != key {
+			t.Errorf("expected: %s, actual: %s", key, lower[i].Key)
+		}
+	}
+}
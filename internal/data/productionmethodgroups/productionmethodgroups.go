@@ -0,0 +1,48 @@
+package productionmethodgroups
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+// ProductionMethodGroup is a single entry in common/production_method_groups.
+// A building has one active production method from each of its groups.
+type ProductionMethodGroup struct {
+	Key                     string       `pdx:",key"`
+	Texture                 string       `pdx:"texture"`
+	AISelection             string       `pdx:"ai_selection"`
+	IsHiddenWhenUnavailable bool         `pdx:"is_hidden_when_unavailable"`
+	ProductionMethods       []entity.Ref `pdx:"production_methods"`
+}
+
+// ProductionMethodGroups are kept in the order the game defines them
+type ProductionMethodGroups = entity.Collection[ProductionMethodGroup]
+
+// Load loads every group from the game's production method groups directory
+func Load() (*ProductionMethodGroups, error) {
+	return entity.LoadDir[ProductionMethodGroup](dirs.ProductionMethodGroups)
+}
+
+// LoadFrom loads every group from the production method groups directory of the root
+func LoadFrom(g dirs.Root) (*ProductionMethodGroups, error) {
+	return entity.LoadInstall[ProductionMethodGroup](g, dirs.ProductionMethodGroups)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*ProductionMethodGroups, error) {
+	return entity.LoadFiles[ProductionMethodGroup](srcs)
+}
+
+// Containing returns the keys of every group which lists the production method, in definition order
+func Containing(groups *ProductionMethodGroups, pm string) []string {
+	var keys []string
+	for _, g := range groups.Values() {
+		for _, ref := range g.ProductionMethods {
+			if ref.Key == pm {
+				keys = append(keys, g.Key)
+				break
+			}
+		}
+	}
+	return keys
+}
@@ -0,0 +1,56 @@
+package productionmethodgroups
+
+import (
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	FoodIndustry   files.DataFile = "testdata/00_food_industry.txt"
+	ExpectedGroups                = 3
+)
+
+func load(t *testing.T) *ProductionMethodGroups {
+	groups, err := LoadFiles([]files.DataFile{FoodIndustry})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	if groups.Len() != ExpectedGroups {
+		t.Fatalf("expected %d groups, actual: %d", ExpectedGroups, groups.Len())
+	}
+	return groups
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	groups := load(t)
+
+	base, _ := groups.Get("pmg_base_building_food_industry")
+	if base.AISelection != "most_productive" {
+		t.Errorf("expected: %s, actual: %s", "most_productive", base.AISelection)
+	}
+	expected := []string{"pm_bakery", "pm_sweeteners", "pm_baking_powder"}
+	if len(base.ProductionMethods) != len(expected) {
+		t.Fatalf("expected: %v, actual: %v", expected, base.ProductionMethods)
+	}
+	for i, pm := range expected {
+		if base.ProductionMethods[i].Key != pm {
+			t.Errorf("expected: %s, actual: %s", pm, base.ProductionMethods[i])
+		}
+	}
+
+	ownership, _ := groups.Get("pmg_ownership_capital_building_food_industry")
+	if !ownership.IsHiddenWhenUnavailable {
+		t.Errorf("expected is_hidden_when_unavailable = yes")
+	}
+}
+
+func TestContaining(t *testing.T) {
+	groups := load(t)
+	keys := Containing(groups, "pm_cannery")
+	if len(keys) != 1 || keys[0] != "pmg_canning" {
+		t.Errorf("unexpected groups: %v", keys)
+	}
+	if keys := Containing(groups, "pm_missing"); len(keys) != 0 {
+		t.Errorf("expected no groups, actual: %v", keys)
+	}
+}
@@ -0,0 +1,134 @@
+package productionmethods
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/data/modifiers"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Scaling is how a building modifier scales with the building
+type Scaling string
+
+const (
+	WorkforceScaled Scaling = "workforce_scaled"
+	LevelScaled     Scaling = "level_scaled"
+	Unscaled        Scaling = "unscaled"
+)
+
+// BuildingModifiers is the `building_modifiers` block of a production method
+type BuildingModifiers struct {
+	WorkforceScaled modifiers.Modifiers `pdx:"workforce_scaled"`
+	LevelScaled     modifiers.Modifiers `pdx:"level_scaled"`
+	Unscaled        modifiers.Modifiers `pdx:"unscaled"`
+}
+
+// each calls fn for every modifier, in the order workforce scaled, level scaled, unscaled
+func (bm BuildingModifiers) each(fn func(Scaling, modifiers.Modifier)) {
+	for _, mod := range bm.WorkforceScaled {
+		fn(WorkforceScaled, mod)
+	}
+	for _, mod := range bm.LevelScaled {
+		fn(LevelScaled, mod)
+	}
+	for _, mod := range bm.Unscaled {
+		fn(Unscaled, mod)
+	}
+}
+
+// ProductionMethod is a single entry in common/production_methods
+type ProductionMethod struct {
+	Key                        string            `pdx:",key"`
+	Texture                    string            `pdx:"texture"`
+	UnlockingTechnologies      []entity.Ref      `pdx:"unlocking_technologies"`
+	UnlockingLaws              []string          `pdx:"unlocking_laws"`
+	DisallowingLaws            []string          `pdx:"disallowing_laws"`
+	UnlockingProductionMethods []string          `pdx:"unlocking_production_methods"`
+	UnlockingPrinciples        []string          `pdx:"unlocking_principles"`
+	BuildingModifiers          BuildingModifiers `pdx:"building_modifiers"`
+	CountryModifiers           *parser.Block     `pdx:"country_modifiers"`
+	StateModifiers             *parser.Block     `pdx:"state_modifiers"`
+	TimedModifiers             []string          `pdx:"timed_modifiers"`
+	RequiredInputGoods         string            `pdx:"required_input_goods"`
+	IsDefault                  bool              `pdx:"is_default"`
+}
+
+// GoodsFlow is an amount of a good consumed or produced by a production method, per level of the building
+type GoodsFlow struct {
+	Good      string
+	Direction modifiers.Direction
+	Amount    float64
+	Scaling   Scaling
+	Modifier  modifiers.Modifier
+}
+
+// Employment is the number of pops of a type employed by a production method, per level of the building
+type Employment struct {
+	PopType  string
+	Amount   float64
+	Scaling  Scaling
+	Modifier modifiers.Modifier
+}
+
+// Goods returns every `goods_input_<good>_add` and `goods_output_<good>_add` building modifier.
+// Multipliers are not included, as they do not name an amount of goods.
+func (pm ProductionMethod) Goods() []GoodsFlow {
+	var flows []GoodsFlow
+	pm.BuildingModifiers.each(func(scaling Scaling, mod modifiers.Modifier) {
+		good, dir, op, ok := modifiers.ParseGoods(mod.Name)
+		if ok && op == modifiers.Add {
+			flows = append(flows, GoodsFlow{Good: good, Direction: dir, Amount: mod.Value, Scaling: scaling, Modifier: mod})
+		}
+	})
+	return flows
+}
+
+// Inputs returns the goods consumed by the production method
+func (pm ProductionMethod) Inputs() []GoodsFlow {
+	return filterGoods(pm.Goods(), modifiers.Input)
+}
+
+// Outputs returns the goods produced by the production method
+func (pm ProductionMethod) Outputs() []GoodsFlow {
+	return filterGoods(pm.Goods(), modifiers.Output)
+}
+
+func filterGoods(flows []GoodsFlow, dir modifiers.Direction) []GoodsFlow {
+	var matches []GoodsFlow
+	for _, flow := range flows {
+		if flow.Direction == dir {
+			matches = append(matches, flow)
+		}
+	}
+	return matches
+}
+
+// Employment returns every `building_employment_<pop>_add` building modifier
+func (pm ProductionMethod) Employment() []Employment {
+	var employment []Employment
+	pm.BuildingModifiers.each(func(scaling Scaling, mod modifiers.Modifier) {
+		popType, op, ok := modifiers.ParseEmployment(mod.Name)
+		if ok && op == modifiers.Add {
+			employment = append(employment, Employment{PopType: popType, Amount: mod.Value, Scaling: scaling, Modifier: mod})
+		}
+	})
+	return employment
+}
+
+// ProductionMethods are kept in the order the game defines them
+type ProductionMethods = entity.Collection[ProductionMethod]
+
+// Load loads every production method from the game's production methods directory
+func Load() (*ProductionMethods, error) {
+	return entity.LoadDir[ProductionMethod](dirs.ProductionMethods)
+}
+
+// LoadFrom loads every production method from the production methods directory of the root
+func LoadFrom(g dirs.Root) (*ProductionMethods, error) {
+	return entity.LoadInstall[ProductionMethod](g, dirs.ProductionMethods)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*ProductionMethods, error) {
+	return entity.LoadFiles[ProductionMethod](srcs)
+}
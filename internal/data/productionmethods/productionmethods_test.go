@@ -0,0 +1,100 @@
+package productionmethods
+
+import (
+	"testing"
+	"vic3-data-reader/internal/data/modifiers"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	FoodIndustry              files.DataFile = "testdata/00_food_industry.txt"
+	ExpectedProductionMethods                = 3
+)
+
+func load(t *testing.T) *ProductionMethods {
+	pms, err := LoadFiles([]files.DataFile{FoodIndustry})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	if pms.Len() != ExpectedProductionMethods {
+		t.Fatalf("expected %d production methods, actual: %d", ExpectedProductionMethods, pms.Len())
+	}
+	return pms
+}
+
+func get(t *testing.T, key string) ProductionMethod {
+	pm, ok := load(t).Get(key)
+	if !ok {
+		t.Fatalf("missing production method %q", key)
+	}
+	return pm
+}
+
+func TestInputs(t *testing.T) {
+	inputs := get(t, "pm_canneries").Inputs()
+	if len(inputs) != 2 {
+		t.Fatalf("expected %d inputs, actual: %d", 2, len(inputs))
+	}
+	expected := []GoodsFlow{
+		{Good: "fish", Direction: modifiers.Input, Amount: 10, Scaling: WorkforceScaled},
+		{Good: "iron", Direction: modifiers.Input, Amount: 5, Scaling: WorkforceScaled},
+	}
+	for i, e := range expected {
+		a := inputs[i]
+		if a.Good != e.Good || a.Direction != e.Direction || a.Amount != e.Amount || a.Scaling != e.Scaling {
+			t.Errorf("expected: %v, actual: %v", e, a)
+		}
+	}
+}
+
+func TestOutputs_ignoresMultipliers(t *testing.T) {
+	outputs := get(t, "pm_canneries").Outputs()
+	if len(outputs) != 1 {
+		t.Fatalf("expected %d output, actual: %d", 1, len(outputs))
+	}
+	if outputs[0].Good != "groceries" || outputs[0].Amount != 40 {
+		t.Errorf("unexpected output: %v", outputs[0])
+	}
+}
+
+func TestGoods_keepsModifierPosition(t *testing.T) {
+	goods := get(t, "pm_bakery").Goods()
+	if len(goods) != 2 {
+		t.Fatalf("expected %d goods, actual: %d", 2, len(goods))
+	}
+	// separated by a comment line
+	if goods[1].Modifier.Pos.Line() != goods[0].Modifier.Pos.Line()+2 {
+		t.Errorf("unexpected modifier lines: %d and %d", goods[0].Modifier.Pos.Line(), goods[1].Modifier.Pos.Line())
+	}
+}
+
+func TestEmployment(t *testing.T) {
+	employment := get(t, "pm_canneries").Employment()
+	if len(employment) != 2 {
+		t.Fatalf("expected %d employment modifiers, actual: %d", 2, len(employment))
+	}
+	if employment[0].PopType != "laborers" || employment[0].Amount != 3500 || employment[0].Scaling != LevelScaled {
+		t.Errorf("unexpected employment: %v", employment[0])
+	}
+	if employment[1].PopType != "machinists" || employment[1].Amount != 1000 {
+		t.Errorf("unexpected employment: %v", employment[1])
+	}
+}
+
+func TestLoadFiles_unlocks(t *testing.T) {
+	canneries := get(t, "pm_canneries")
+	if len(canneries.UnlockingTechnologies) != 1 || canneries.UnlockingTechnologies[0].Key != "canneries" {
+		t.Errorf("unexpected unlocking_technologies: %v", canneries.UnlockingTechnologies)
+	}
+
+	private := get(t, "pm_privately_owned_building_food_industry")
+	if len(private.UnlockingLaws) != 2 || private.UnlockingLaws[1] != "law_interventionism" {
+		t.Errorf("unexpected unlocking_laws: %v", private.UnlockingLaws)
+	}
+	if len(private.DisallowingLaws) != 1 || private.DisallowingLaws[0] != "law_command_economy" {
+		t.Errorf("unexpected disallowing_laws: %v", private.DisallowingLaws)
+	}
+	if len(private.Inputs()) != 0 {
+		t.Errorf("expected no inputs, actual: %v", private.Inputs())
+	}
+}
@@ -0,0 +1,159 @@
+package scriptvalues
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Context is a set of fixed numbers, by name, for values which would otherwise come from the game state
+type Context map[string]float64
+
+// Result is the outcome of evaluating a script value.
+// If Unresolved is set, the value depends on the game state and Value is meaningless.
+type Result struct {
+	Value      float64
+	Unresolved *Unresolved
+}
+
+func (r Result) IsResolved() bool {
+	return r.Unresolved == nil
+}
+
+func (r Result) String() string {
+	if r.Unresolved != nil {
+		return r.Unresolved.String()
+	}
+	return strconv.FormatFloat(r.Value, 'f', -1, 64)
+}
+
+// Unresolved is the first part of a script value which could not be evaluated without the game state,
+// such as a trigger, a scope, or a name which is neither a script value nor in the Context
+type Unresolved struct {
+	Ref    string
+	Source entity.Source
+}
+
+func (u *Unresolved) String() string {
+	return fmt.Sprintf("unresolved %q at %s", u.Ref, u.Source)
+}
+
+// Evaluator evaluates script values which only do arithmetic on numbers, other script values,
+// and names in the Context. Names in the Context take priority over script values of the same name.
+type Evaluator struct {
+	values *ScriptValues
+	ctx    Context
+	// src is the source of the value being evaluated, for positions
+	src      entity.Source
+	visiting map[string]bool
+}
+
+func NewEvaluator(values *ScriptValues, ctx Context) *Evaluator {
+	return &Evaluator{values: values, ctx: ctx, visiting: map[string]bool{}}
+}
+
+// Eval evaluates the script value with the key
+func (e *Evaluator) Eval(key string) (Result, error) {
+	entry, ok := e.values.Entry(key)
+	if !ok {
+		return Result{}, fmt.Errorf("undefined script value %q", key)
+	}
+	if e.visiting[key] {
+		return Result{}, fmt.Errorf("%s: script value %q refers to itself", entry.Source, key)
+	}
+	e.visiting[key] = true
+	defer delete(e.visiting, key)
+
+	src := e.src
+	e.src = entry.Source
+	defer func() { e.src = src }()
+	return e.EvalValue(entry.Value.Value)
+}
+
+// EvalValue evaluates a value written in script, such as a modifier value in a building
+func (e *Evaluator) EvalValue(val parser.Value) (Result, error) {
+	switch v := val.(type) {
+	case *parser.Scalar:
+		return e.evalScalar(v)
+	case *parser.Block:
+		return e.evalBlock(v)
+	default:
+		return Result{}, fmt.Errorf("expected a script value, actual: %T", val)
+	}
+}
+
+func (e *Evaluator) evalScalar(s *parser.Scalar) (Result, error) {
+	if n, err := strconv.ParseFloat(s.Text(), 64); err == nil {
+		return Result{Value: n}, nil
+	}
+	if n, ok := e.ctx[s.Text()]; ok {
+		return Result{Value: n}, nil
+	}
+	if _, ok := e.values.Entry(s.Text()); ok {
+		return e.Eval(s.Text())
+	}
+	return e.unresolved(s.Text(), s), nil
+}
+
+// evalBlock applies each operation of the block in order, starting from 0
+func (e *Evaluator) evalBlock(blk *parser.Block) (Result, error) {
+	if len(blk.Values()) > 0 {
+		return Result{}, e.errorf(blk, "expected a block of operations")
+	}
+
+	var acc float64
+	for _, f := range blk.Fields() {
+		if f.Name() == "desc" {
+			continue
+		}
+		if f.Name() == "round" {
+			if s, ok := f.Value.(*parser.Scalar); !ok || (s.Text() != "yes" && s.Text() != "no") {
+				return Result{}, e.errorf(f, "round must be yes or no")
+			} else if s.Text() == "yes" {
+				acc = math.Round(acc)
+			}
+			continue
+		}
+
+		op, ok := operations[f.Name()]
+		if !ok {
+			return e.unresolved(f.Name(), f), nil
+		}
+		operand, err := e.EvalValue(f.Value)
+		if err != nil || !operand.IsResolved() {
+			return operand, err
+		}
+		if f.Name() == "divide" && operand.Value == 0 {
+			return Result{}, e.errorf(f, "division by zero")
+		}
+		acc = op(acc, operand.Value)
+	}
+	return Result{Value: acc}, nil
+}
+
+// operations combine the value so far with an operand
+var operations = map[string]func(acc, operand float64) float64{
+	"value":    func(_, operand float64) float64 { return operand },
+	"add":      func(acc, operand float64) float64 { return acc + operand },
+	"subtract": func(acc, operand float64) float64 { return acc - operand },
+	"multiply": func(acc, operand float64) float64 { return acc * operand },
+	"divide":   func(acc, operand float64) float64 { return acc / operand },
+	// min and max are bounds, so `min = 5` means the value is at least 5
+	"min": math.Max,
+	"max": math.Min,
+}
+
+func (e *Evaluator) unresolved(ref string, node parser.Node) Result {
+	return Result{Unresolved: &Unresolved{Ref: ref, Source: e.at(node)}}
+}
+
+func (e *Evaluator) errorf(node parser.Node, format string, args ...any) error {
+	return fmt.Errorf("%s: %s", e.at(node), fmt.Sprintf(format, args...))
+}
+
+// at is the source of a node within the value being evaluated
+func (e *Evaluator) at(node parser.Node) entity.Source {
+	return entity.Source{File: e.src.File, Pos: node.Start(), Origin: e.src.Origin}
+}
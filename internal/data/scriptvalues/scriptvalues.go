@@ -0,0 +1,36 @@
+package scriptvalues
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// ScriptValue is a single entry in common/script_values.
+// It is either a number, a reference to another value, or a block of operations, so it is kept as written.
+type ScriptValue struct {
+	Value parser.Value
+}
+
+func (s *ScriptValue) UnmarshalPDX(val parser.Value) error {
+	s.Value = val
+	return nil
+}
+
+// ScriptValues are kept in the order the game defines them
+type ScriptValues = entity.Collection[ScriptValue]
+
+// Load loads every script value from the game's script values directory
+func Load() (*ScriptValues, error) {
+	return entity.LoadDir[ScriptValue](dirs.ScriptValues)
+}
+
+// LoadFrom loads every script value from the script values directory of the root
+func LoadFrom(g dirs.Root) (*ScriptValues, error) {
+	return entity.LoadInstall[ScriptValue](g, dirs.ScriptValues)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*ScriptValues, error) {
+	return entity.LoadFiles[ScriptValue](srcs)
+}
@@ -0,0 +1,98 @@
+package scriptvalues
+
+import (
+	"math"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+const (
+	SmokeSample files.DataFile = "testdata/00_script_values.txt"
+)
+
+func load(t *testing.T) *ScriptValues {
+	values, err := LoadFiles([]files.DataFile{SmokeSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	return values
+}
+
+func TestEval(t *testing.T) {
+	e := NewEvaluator(load(t), Context{"wage_rate": 0.5})
+	tests := map[string]float64{
+		"base_construction_cost":   100,
+		"double_construction_cost": 200,
+		"clamped":                  0,
+		"rounded":                  3,
+		"with_context":             100,
+		"bounded":                  22,
+	}
+	for key, expected := range tests {
+		result, err := e.Eval(key)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", key, err)
+		} else if !result.IsResolved() {
+			t.Errorf("%s: expected a resolved result, actual: %s", key, result)
+		} else if math.Abs(result.Value-expected) > 1e-9 {
+			t.Errorf("%s: expected: %v, actual: %v", key, expected, result.Value)
+		}
+	}
+}
+
+func TestEval_unresolved(t *testing.T) {
+	e := NewEvaluator(load(t), nil)
+	tests := map[string]struct {
+		ref       string
+		line, col int
+	}{
+		"state_dependent": {"if", 37, 2},
+		"scoped":          {"scope:building.level", 44, 10},
+		"with_context":    {"wage_rate", 25, 10},
+	}
+	for key, expected := range tests {
+		result, err := e.Eval(key)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", key, err)
+			continue
+		}
+		u := result.Unresolved
+		if u == nil {
+			t.Errorf("%s: expected an unresolved result, actual: %s", key, result)
+			continue
+		}
+		if u.Ref != expected.ref || u.Source.Line() != expected.line || u.Source.Pos.Col() != expected.col {
+			t.Errorf("%s: expected %q at %d:%d, actual: %s", key, expected.ref, expected.line, expected.col, u)
+		}
+		if u.Source.File != string(SmokeSample) {
+			t.Errorf("%s: expected: %s, actual: %s", key, SmokeSample, u.Source.File)
+		}
+	}
+}
+
+func TestEval_errors(t *testing.T) {
+	e := NewEvaluator(load(t), nil)
+	for _, key := range []string{"self_a", "divide_by_zero", "DOES-NOT-EXIST"} {
+		if _, err := e.Eval(key); err == nil {
+			t.Errorf("%s: expected an error", key)
+		}
+	}
+}
+
+func TestEvalValue(t *testing.T) {
+	file, err := parser.ParseFile(SmokeSample)
+	if err != nil {
+		t.Fatalf("ParseFile returned unexpected error: %v", err)
+	}
+	f, _ := file.Get("double_construction_cost")
+
+	e := NewEvaluator(load(t), Context{"base_construction_cost": 1})
+	result, err := e.EvalValue(f.Value)
+	if err != nil {
+		t.Fatalf("EvalValue returned unexpected error: %v", err)
+	}
+	if result.Value != 2 {
+		t.Errorf("expected the context to take priority; expected: %v, actual: %v", 2, result.Value)
+	}
+}
@@ -0,0 +1,66 @@
+package technologies
+
+import (
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/data/modifiers"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Technology is a single entry in common/technology/technologies
+type Technology struct {
+	Key                   string              `pdx:",key"`
+	Era                   string              `pdx:"era"`
+	Category              string              `pdx:"category"`
+	Texture               string              `pdx:"texture"`
+	Modifier              modifiers.Modifiers `pdx:"modifier"`
+	UnlockingTechnologies []entity.Ref        `pdx:"unlocking_technologies"`
+	CanResearch           *bool               `pdx:"can_research"`
+	AIWeight              *parser.Block       `pdx:"ai_weight"`
+}
+
+// IsResearchable is true unless the technology sets `can_research = no`
+func (t Technology) IsResearchable() bool {
+	return t.CanResearch == nil || *t.CanResearch
+}
+
+// Era is a single entry in common/technology/eras
+type Era struct {
+	Key            string  `pdx:",key"`
+	TechnologyCost float64 `pdx:"technology_cost"`
+}
+
+// Technologies are kept in the order the game defines them
+type Technologies = entity.Collection[Technology]
+
+// Eras are kept in the order the game defines them, which is also their chronological order
+type Eras = entity.Collection[Era]
+
+// Load loads every technology from the game's technologies directory
+func Load() (*Technologies, error) {
+	return entity.LoadDir[Technology](dirs.Technologies)
+}
+
+// LoadFrom loads every technology from the technologies directory of the root
+func LoadFrom(g dirs.Root) (*Technologies, error) {
+	return entity.LoadInstall[Technology](g, dirs.Technologies)
+}
+
+func LoadFiles[S files.Source](srcs []S) (*Technologies, error) {
+	return entity.LoadFiles[Technology](srcs)
+}
+
+// LoadEras loads every era from the game's eras directory
+func LoadEras() (*Eras, error) {
+	return entity.LoadDir[Era](dirs.TechnologyEras)
+}
+
+// LoadErasFrom loads every era from the eras directory of the root
+func LoadErasFrom(g dirs.Root) (*Eras, error) {
+	return entity.LoadInstall[Era](g, dirs.TechnologyEras)
+}
+
+func LoadErasFiles[S files.Source](srcs []S) (*Eras, error) {
+	return entity.LoadFiles[Era](srcs)
+}
@@ -0,0 +1,133 @@
+package technologies
+
+import (
+	"slices"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	ErasSample       files.DataFile = "testdata/00_eras.txt"
+	ProductionSample files.DataFile = "testdata/10_production.txt"
+	Cycle            files.DataFile = "testdata/cycle.txt"
+	Undefined        files.DataFile = "testdata/undefined.txt"
+)
+
+func loadEras(t *testing.T) *Eras {
+	eras, err := LoadErasFiles([]files.DataFile{ErasSample})
+	if err != nil {
+		t.Fatalf("LoadErasFiles returned unexpected error: %v", err)
+	}
+	return eras
+}
+
+func loadTree(t *testing.T) *Tree {
+	techs, err := LoadFiles([]files.DataFile{ProductionSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+	tree, err := NewTree(techs, loadEras(t))
+	if err != nil {
+		t.Fatalf("NewTree returned unexpected error: %v", err)
+	}
+	return tree
+}
+
+func TestLoadFiles_fields(t *testing.T) {
+	techs, err := LoadFiles([]files.DataFile{ProductionSample})
+	if err != nil {
+		t.Fatalf("LoadFiles returned unexpected error: %v", err)
+	}
+
+	railways, _ := techs.Get("railways")
+	if railways.Era != "era_3" || railways.Category != "production" {
+		t.Errorf("unexpected railways: %+v", railways)
+	}
+	if v, ok := railways.Modifier.Get("state_infrastructure_add"); !ok || v != 5 {
+		t.Errorf("expected state_infrastructure_add = 5, actual: %v", v)
+	}
+	if !railways.IsResearchable() {
+		t.Errorf("technologies should be researchable by default")
+	}
+
+	lostArt, _ := techs.Get("lost_art")
+	if lostArt.IsResearchable() {
+		t.Errorf("expected can_research = no")
+	}
+}
+
+func TestPrerequisites(t *testing.T) {
+	prereqs, err := loadTree(t).Prerequisites("railways")
+	if err != nil {
+		t.Fatalf("Prerequisites returned unexpected error: %v", err)
+	}
+	expected := []string{"manufacturies", "mechanized_workshops", "enclosure", "steam_engine"}
+	if !slices.Equal(expected, prereqs) {
+		t.Errorf("expected: %v, actual: %v", expected, prereqs)
+	}
+}
+
+func TestPrerequisites_undefinedReturnsError(t *testing.T) {
+	_, err := loadTree(t).Prerequisites("missing")
+	if err == nil {
+		t.Errorf("Prerequisites did not return an error for an undefined technology")
+	}
+}
+
+func TestPath(t *testing.T) {
+	tree := loadTree(t)
+
+	path, cost, err := tree.Path("railways", nil)
+	if err != nil {
+		t.Fatalf("Path returned unexpected error: %v", err)
+	}
+	if len(path) != 5 || path[len(path)-1] != "railways" {
+		t.Errorf("unexpected path: %v", path)
+	}
+	// two era_1, two era_2, one era_3
+	if expected := 2*5000.0 + 2*8000.0 + 12500.0; cost != expected {
+		t.Errorf("expected: %v, actual: %v", expected, cost)
+	}
+
+	researched := map[string]bool{"manufacturies": true, "mechanized_workshops": true}
+	path, cost, err = tree.Path("railways", researched)
+	if err != nil {
+		t.Fatalf("Path returned unexpected error: %v", err)
+	}
+	expected := []string{"enclosure", "steam_engine", "railways"}
+	if !slices.Equal(expected, path) {
+		t.Errorf("expected: %v, actual: %v", expected, path)
+	}
+	if cost != 5000+8000+12500 {
+		t.Errorf("expected: %v, actual: %v", 5000+8000+12500, cost)
+	}
+}
+
+func TestOrder(t *testing.T) {
+	order := loadTree(t).Order()
+	expected := []string{"enclosure", "manufacturies", "lost_art", "mechanized_workshops", "steam_engine", "railways"}
+	if !slices.Equal(expected, order) {
+		t.Errorf("expected: %v, actual: %v", expected, order)
+	}
+}
+
+func TestUnlocks(t *testing.T) {
+	unlocks := loadTree(t).Unlocks("manufacturies")
+	expected := []string{"mechanized_workshops", "steam_engine"}
+	if !slices.Equal(expected, unlocks) {
+		t.Errorf("expected: %v, actual: %v", expected, unlocks)
+	}
+}
+
+func TestNewTree_invalidGraphs(t *testing.T) {
+	for _, df := range []files.DataFile{Cycle, Undefined} {
+		techs, err := LoadFiles([]files.DataFile{df})
+		if err != nil {
+			t.Fatalf("LoadFiles returned unexpected error: %v", err)
+		}
+		_, err = NewTree(techs, loadEras(t))
+		if err == nil {
+			t.Errorf("%s: NewTree did not return an error", df)
+		}
+	}
+}
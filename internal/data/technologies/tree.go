@@ -0,0 +1,172 @@
+package technologies
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Tree is the graph of technologies, linked by their unlocking_technologies prerequisites
+type Tree struct {
+	techs    *Technologies
+	eras     *Eras
+	eraIndex map[string]int
+	defIndex map[string]int
+	unlocks  map[string][]string
+}
+
+// NewTree checks that every era and prerequisite is defined, and that no technology depends on itself
+func NewTree(techs *Technologies, eras *Eras) (*Tree, error) {
+	t := &Tree{
+		techs:    techs,
+		eras:     eras,
+		eraIndex: map[string]int{},
+		defIndex: map[string]int{},
+		unlocks:  map[string][]string{},
+	}
+	for i, key := range eras.Keys() {
+		t.eraIndex[key] = i
+	}
+
+	for i, entry := range techs.Entries() {
+		t.defIndex[entry.Key] = i
+		if _, ok := t.eraIndex[entry.Value.Era]; !ok {
+			return nil, fmt.Errorf("%s: technology %q has undefined era %q", entry.Source, entry.Key, entry.Value.Era)
+		}
+		for _, prereq := range entry.Value.UnlockingTechnologies {
+			if _, ok := techs.Get(prereq.Key); !ok {
+				return nil, fmt.Errorf("%s: technology %q requires undefined technology %q", entry.Source.At(prereq.Pos), entry.Key, prereq)
+			}
+			t.unlocks[prereq.Key] = append(t.unlocks[prereq.Key], entry.Key)
+		}
+	}
+
+	if err := t.checkCycles(); err != nil {
+		return nil, err
+	}
+	return t, nil
+}
+
+func (t *Tree) checkCycles() error {
+	const (
+		visiting = 1
+		done     = 2
+	)
+	state := map[string]int{}
+	var visit func(string) error
+	visit = func(key string) error {
+		switch state[key] {
+		case visiting:
+			entry, _ := t.techs.Entry(key)
+			return fmt.Errorf("%s: technology %q is its own prerequisite", entry.Source, key)
+		case done:
+			return nil
+		}
+		state[key] = visiting
+		tech, _ := t.techs.Get(key)
+		for _, prereq := range tech.UnlockingTechnologies {
+			if err := visit(prereq.Key); err != nil {
+				return err
+			}
+		}
+		state[key] = done
+		return nil
+	}
+
+	for _, key := range t.techs.Keys() {
+		if err := visit(key); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Unlocks returns the technologies which directly require the technology, in definition order
+func (t *Tree) Unlocks(key string) []string {
+	return t.unlocks[key]
+}
+
+// Prerequisites returns every technology which must be researched before the technology,
+// ordered so that each technology comes after its own prerequisites
+func (t *Tree) Prerequisites(key string) ([]string, error) {
+	path, _, err := t.Path(key, nil)
+	if err != nil {
+		return nil, err
+	}
+	return path[:len(path)-1], nil
+}
+
+// Cost returns the research cost of the technology, which is set by its era
+func (t *Tree) Cost(key string) (float64, error) {
+	tech, ok := t.techs.Get(key)
+	if !ok {
+		return 0, fmt.Errorf("technology %q is not defined", key)
+	}
+	era, _ := t.eras.Get(tech.Era)
+	return era.TechnologyCost, nil
+}
+
+// Path returns the technologies to research, in order, to reach the target,
+// skipping those already researched. The target is the last technology in the path, unless it is already researched.
+// The total research cost of the path is also returned.
+func (t *Tree) Path(target string, researched map[string]bool) ([]string, float64, error) {
+	if _, ok := t.techs.Get(target); !ok {
+		return nil, 0, fmt.Errorf("technology %q is not defined", target)
+	}
+
+	var path []string
+	var total float64
+	seen := map[string]bool{}
+	var visit func(string)
+	visit = func(key string) {
+		if seen[key] || researched[key] {
+			return
+		}
+		seen[key] = true
+		tech, _ := t.techs.Get(key)
+		for _, prereq := range tech.UnlockingTechnologies {
+			visit(prereq.Key)
+		}
+		cost, _ := t.Cost(key)
+		total += cost
+		path = append(path, key)
+	}
+	visit(target)
+	return path, total, nil
+}
+
+// Order returns every technology in an order where each comes after its prerequisites.
+// Among technologies which are ready to research, earlier eras come first, then definition order.
+func (t *Tree) Order() []string {
+	remaining := map[string]int{}
+	var ready []string
+	for _, entry := range t.techs.Entries() {
+		remaining[entry.Key] = len(entry.Value.UnlockingTechnologies)
+		if remaining[entry.Key] == 0 {
+			ready = append(ready, entry.Key)
+		}
+	}
+
+	before := func(a, b string) int {
+		ta, _ := t.techs.Get(a)
+		tb, _ := t.techs.Get(b)
+		if d := t.eraIndex[ta.Era] - t.eraIndex[tb.Era]; d != 0 {
+			return d
+		}
+		return t.defIndex[a] - t.defIndex[b]
+	}
+
+	order := make([]string, 0, t.techs.Len())
+	for len(ready) > 0 {
+		slices.SortFunc(ready, before)
+		key := ready[0]
+		ready = ready[1:]
+		order = append(order, key)
+		for _, next := range t.unlocks[key] {
+			remaining[next]--
+			if remaining[next] == 0 {
+				ready = append(ready, next)
+			}
+		}
+	}
+	return order
+}
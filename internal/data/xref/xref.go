@@ -0,0 +1,336 @@
+package xref
+
+import (
+	"fmt"
+	"vic3-data-reader/internal/data/buildinggroups"
+	"vic3-data-reader/internal/data/buildings"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/data/goods"
+	"vic3-data-reader/internal/data/modifiers"
+	"vic3-data-reader/internal/data/productionmethodgroups"
+	"vic3-data-reader/internal/data/productionmethods"
+	"vic3-data-reader/internal/data/technologies"
+	"vic3-data-reader/internal/read/dirs"
+)
+
+// Data is the set of loaded entities which reference each other
+type Data struct {
+	Goods                  *goods.Goods
+	Buildings              *buildings.Buildings
+	BuildingGroups         *buildinggroups.BuildingGroups
+	ProductionMethodGroups *productionmethodgroups.ProductionMethodGroups
+	ProductionMethods      *productionmethods.ProductionMethods
+	Technologies           *technologies.Technologies
+}
+
+// Load loads every entity type from the game directories
+func Load() (Data, error) {
+	g, err := dirs.DefaultGameInstall()
+	if err != nil {
+		return Data{}, err
+	}
+	return LoadFrom(g)
+}
+
+// LoadFrom loads every entity type from the root, which may be a game install or a stack of the game and mods
+func LoadFrom(g dirs.Root) (Data, error) {
+	var data Data
+	var err error
+	if data.Goods, err = goods.LoadFrom(g); err != nil {
+		return data, err
+	}
+	if data.Buildings, err = buildings.LoadFrom(g); err != nil {
+		return data, err
+	}
+	if data.BuildingGroups, err = buildinggroups.LoadFrom(g); err != nil {
+		return data, err
+	}
+	if data.ProductionMethodGroups, err = productionmethodgroups.LoadFrom(g); err != nil {
+		return data, err
+	}
+	if data.ProductionMethods, err = productionmethods.LoadFrom(g); err != nil {
+		return data, err
+	}
+	if data.Technologies, err = technologies.LoadFrom(g); err != nil {
+		return data, err
+	}
+	return data, nil
+}
+
+type Good struct {
+	*entity.Entry[goods.Good]
+	ProducedBy []*ProductionMethod
+	ConsumedBy []*ProductionMethod
+}
+
+type Building struct {
+	*entity.Entry[buildings.Building]
+	Group                  *BuildingGroup
+	ProductionMethodGroups []*ProductionMethodGroup
+	UnlockingTechnologies  []*Technology
+}
+
+type BuildingGroup struct {
+	*entity.Entry[buildinggroups.BuildingGroup]
+	Parent    *BuildingGroup
+	Children  []*BuildingGroup
+	Buildings []*Building
+}
+
+type ProductionMethodGroup struct {
+	*entity.Entry[productionmethodgroups.ProductionMethodGroup]
+	ProductionMethods []*ProductionMethod
+	Buildings         []*Building
+}
+
+type ProductionMethod struct {
+	*entity.Entry[productionmethods.ProductionMethod]
+	Groups                []*ProductionMethodGroup
+	Inputs                []GoodsLink
+	Outputs               []GoodsLink
+	UnlockingTechnologies []*Technology
+}
+
+// GoodsLink is a goods input or output of a production method, linked to its good
+type GoodsLink struct {
+	productionmethods.GoodsFlow
+	Good *Good
+}
+
+type Technology struct {
+	*entity.Entry[technologies.Technology]
+	Prerequisites []*Technology
+}
+
+// Dangling is a reference to an entity which is not defined
+type Dangling struct {
+	// From is the key of the entity containing the reference
+	From string
+	// Field is the key the reference is made in, e.g. building_group
+	Field string
+	// Ref is the undefined key
+	Ref string
+	// Source is where the reference is written
+	Source entity.Source
+}
+
+func (d Dangling) Error() string {
+	return fmt.Sprintf("%s: %s references undefined %q in %s", d.Source, d.From, d.Ref, d.Field)
+}
+
+// Index is the linked entities, keyed by name.
+// Every reference which could not be linked is listed in Dangling, in the order it was found.
+type Index struct {
+	Goods                  map[string]*Good
+	Buildings              map[string]*Building
+	BuildingGroups         map[string]*BuildingGroup
+	ProductionMethodGroups map[string]*ProductionMethodGroup
+	ProductionMethods      map[string]*ProductionMethod
+	Technologies           map[string]*Technology
+	Dangling               []Dangling
+
+	// data is kept so entities can be visited in definition order
+	data Data
+}
+
+// Resolve links every reference between the entities. Any collection in data may be nil.
+func Resolve(data Data) *Index {
+	ix := &Index{
+		data:                   data,
+		Goods:                  map[string]*Good{},
+		Buildings:              map[string]*Building{},
+		BuildingGroups:         map[string]*BuildingGroup{},
+		ProductionMethodGroups: map[string]*ProductionMethodGroup{},
+		ProductionMethods:      map[string]*ProductionMethod{},
+		Technologies:           map[string]*Technology{},
+	}
+	ix.index(data)
+	ix.linkTechnologies()
+	ix.linkBuildingGroups()
+	ix.linkProductionMethods()
+	ix.linkProductionMethodGroups()
+	ix.linkBuildings()
+	return ix
+}
+
+func (ix *Index) index(data Data) {
+	for _, e := range entries(data.Goods) {
+		ix.Goods[e.Key] = &Good{Entry: e}
+	}
+	for _, e := range entries(data.Buildings) {
+		ix.Buildings[e.Key] = &Building{Entry: e}
+	}
+	for _, e := range entries(data.BuildingGroups) {
+		ix.BuildingGroups[e.Key] = &BuildingGroup{Entry: e}
+	}
+	for _, e := range entries(data.ProductionMethodGroups) {
+		ix.ProductionMethodGroups[e.Key] = &ProductionMethodGroup{Entry: e}
+	}
+	for _, e := range entries(data.ProductionMethods) {
+		ix.ProductionMethods[e.Key] = &ProductionMethod{Entry: e}
+	}
+	for _, e := range entries(data.Technologies) {
+		ix.Technologies[e.Key] = &Technology{Entry: e}
+	}
+}
+
+func entries[T any](c *entity.Collection[T]) []*entity.Entry[T] {
+	if c == nil {
+		return nil
+	}
+	return c.Entries()
+}
+
+func (ix *Index) dangling(from, field, ref string, src entity.Source) {
+	ix.Dangling = append(ix.Dangling, Dangling{From: from, Field: field, Ref: ref, Source: src})
+}
+
+// technologies looks up each ref, recording any which are undefined at the position of the ref within src
+func (ix *Index) technologies(from, field string, refs []entity.Ref, src entity.Source) []*Technology {
+	var techs []*Technology
+	for _, ref := range refs {
+		tech, ok := ix.Technologies[ref.Key]
+		if !ok {
+			ix.dangling(from, field, ref.Key, src.At(ref.Pos))
+			continue
+		}
+		techs = append(techs, tech)
+	}
+	return techs
+}
+
+func (ix *Index) linkTechnologies() {
+	for _, e := range entries(ix.data.Technologies) {
+		tech := ix.Technologies[e.Key]
+		tech.Prerequisites = ix.technologies(tech.Key, "unlocking_technologies", tech.Value.UnlockingTechnologies, tech.Source)
+	}
+}
+
+func (ix *Index) linkBuildingGroups() {
+	for _, e := range entries(ix.data.BuildingGroups) {
+		group := ix.BuildingGroups[e.Key]
+		parent := group.Value.ParentGroup
+		if parent.Key == "" {
+			continue
+		}
+		if p, ok := ix.BuildingGroups[parent.Key]; ok {
+			group.Parent = p
+			p.Children = append(p.Children, group)
+		} else {
+			ix.dangling(group.Key, "parent_group", parent.Key, group.Source.At(parent.Pos))
+		}
+	}
+}
+
+func (ix *Index) linkProductionMethods() {
+	for _, e := range entries(ix.data.ProductionMethods) {
+		pm := ix.ProductionMethods[e.Key]
+		pm.UnlockingTechnologies = ix.technologies(pm.Key, "unlocking_technologies", pm.Value.UnlockingTechnologies, pm.Source)
+
+		for _, flow := range pm.Value.Goods() {
+			good, ok := ix.Goods[flow.Good]
+			if !ok {
+				ix.dangling(pm.Key, flow.Modifier.Name, flow.Good, pm.Source.At(flow.Modifier.Pos))
+				continue
+			}
+			link := GoodsLink{GoodsFlow: flow, Good: good}
+			if flow.Direction == modifiers.Input {
+				pm.Inputs = append(pm.Inputs, link)
+				good.ConsumedBy = append(good.ConsumedBy, pm)
+			} else {
+				pm.Outputs = append(pm.Outputs, link)
+				good.ProducedBy = append(good.ProducedBy, pm)
+			}
+		}
+	}
+}
+
+func (ix *Index) linkProductionMethodGroups() {
+	for _, e := range entries(ix.data.ProductionMethodGroups) {
+		group := ix.ProductionMethodGroups[e.Key]
+		for _, ref := range group.Value.ProductionMethods {
+			pm, ok := ix.ProductionMethods[ref.Key]
+			if !ok {
+				ix.dangling(group.Key, "production_methods", ref.Key, group.Source.At(ref.Pos))
+				continue
+			}
+			group.ProductionMethods = append(group.ProductionMethods, pm)
+			pm.Groups = append(pm.Groups, group)
+		}
+	}
+}
+
+func (ix *Index) linkBuildings() {
+	for _, e := range entries(ix.data.Buildings) {
+		b := ix.Buildings[e.Key]
+		ref := b.Value.BuildingGroup
+		if group, ok := ix.BuildingGroups[ref.Key]; ok {
+			b.Group = group
+			group.Buildings = append(group.Buildings, b)
+		} else if ref.Key != "" {
+			ix.dangling(b.Key, "building_group", ref.Key, b.Source.At(ref.Pos))
+		}
+
+		b.UnlockingTechnologies = ix.technologies(b.Key, "unlocking_technologies", b.Value.UnlockingTechnologies, b.Source)
+
+		for _, ref := range b.Value.ProductionMethodGroups {
+			group, ok := ix.ProductionMethodGroups[ref.Key]
+			if !ok {
+				ix.dangling(b.Key, "production_method_groups", ref.Key, b.Source.At(ref.Pos))
+				continue
+			}
+			b.ProductionMethodGroups = append(b.ProductionMethodGroups, group)
+			group.Buildings = append(group.Buildings, b)
+		}
+	}
+}
+
+// ProductionMethods returns every production method available to the building
+func (b *Building) ProductionMethods() []*ProductionMethod {
+	var pms []*ProductionMethod
+	for _, group := range b.ProductionMethodGroups {
+		pms = append(pms, group.ProductionMethods...)
+	}
+	return pms
+}
+
+// BuildingsProducing returns every building with a production method that outputs the good, in definition order
+func (ix *Index) BuildingsProducing(good string) []*Building {
+	return ix.buildingsWith(good, func(pm *ProductionMethod) []GoodsLink { return pm.Outputs })
+}
+
+// BuildingsConsuming returns every building with a production method that inputs the good, in definition order
+func (ix *Index) BuildingsConsuming(good string) []*Building {
+	return ix.buildingsWith(good, func(pm *ProductionMethod) []GoodsLink { return pm.Inputs })
+}
+
+func (ix *Index) buildingsWith(good string, links func(*ProductionMethod) []GoodsLink) []*Building {
+	var matches []*Building
+	for _, e := range entries(ix.data.Buildings) {
+		b := ix.Buildings[e.Key]
+		if hasGood(b, good, links) {
+			matches = append(matches, b)
+		}
+	}
+	return matches
+}
+
+func hasGood(b *Building, good string, links func(*ProductionMethod) []GoodsLink) bool {
+	for _, pm := range b.ProductionMethods() {
+		for _, link := range links(pm) {
+			if link.Good.Key == good {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// Errors returns the dangling references as errors, e.g. for printing
+func (ix *Index) Errors() []error {
+	errs := make([]error, len(ix.Dangling))
+	for i, d := range ix.Dangling {
+		errs[i] = d
+	}
+	return errs
+}
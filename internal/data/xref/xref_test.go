@@ -0,0 +1,160 @@
+package xref
+
+import (
+	"testing"
+	"vic3-data-reader/internal/data/buildinggroups"
+	"vic3-data-reader/internal/data/buildings"
+	"vic3-data-reader/internal/data/goods"
+	"vic3-data-reader/internal/data/productionmethodgroups"
+	"vic3-data-reader/internal/data/productionmethods"
+	"vic3-data-reader/internal/data/technologies"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	Goods                  files.DataFile = "testdata/goods.txt"
+	Buildings              files.DataFile = "testdata/buildings.txt"
+	BuildingGroups         files.DataFile = "testdata/building_groups.txt"
+	ProductionMethodGroups files.DataFile = "testdata/production_method_groups.txt"
+	ProductionMethods      files.DataFile = "testdata/production_methods.txt"
+	Technologies           files.DataFile = "testdata/technologies.txt"
+)
+
+func resolve(t *testing.T) *Index {
+	var data Data
+	var err error
+	if data.Goods, err = goods.LoadFiles([]files.DataFile{Goods}); err != nil {
+		t.Fatalf("could not load goods: %v", err)
+	}
+	if data.Buildings, err = buildings.LoadFiles([]files.DataFile{Buildings}); err != nil {
+		t.Fatalf("could not load buildings: %v", err)
+	}
+	if data.BuildingGroups, err = buildinggroups.LoadFiles([]files.DataFile{BuildingGroups}); err != nil {
+		t.Fatalf("could not load building groups: %v", err)
+	}
+	if data.ProductionMethodGroups, err = productionmethodgroups.LoadFiles([]files.DataFile{ProductionMethodGroups}); err != nil {
+		t.Fatalf("could not load production method groups: %v", err)
+	}
+	if data.ProductionMethods, err = productionmethods.LoadFiles([]files.DataFile{ProductionMethods}); err != nil {
+		t.Fatalf("could not load production methods: %v", err)
+	}
+	if data.Technologies, err = technologies.LoadFiles([]files.DataFile{Technologies}); err != nil {
+		t.Fatalf("could not load technologies: %v", err)
+	}
+	return Resolve(data)
+}
+
+func keys(bs []*Building) []string {
+	var ks []string
+	for _, b := range bs {
+		ks = append(ks, b.Key)
+	}
+	return ks
+}
+
+func TestResolve_buildingLinks(t *testing.T) {
+	ix := resolve(t)
+	arms := ix.Buildings["building_arms_industry"]
+
+	if arms.Group == nil || arms.Group.Key != "bg_heavy_industry" {
+		t.Fatalf("expected building group bg_heavy_industry, actual: %v", arms.Group)
+	}
+	if arms.Group.Parent == nil || arms.Group.Parent.Key != "bg_manufacturing" {
+		t.Errorf("expected parent group bg_manufacturing, actual: %v", arms.Group.Parent)
+	}
+	if len(arms.UnlockingTechnologies) != 1 || len(arms.UnlockingTechnologies[0].Prerequisites) != 1 {
+		t.Errorf("expected mass_production to link to manufacturies")
+	}
+	if len(arms.ProductionMethodGroups) != 1 {
+		t.Errorf("expected 1 linked production method group, actual: %d", len(arms.ProductionMethodGroups))
+	}
+
+	pms := arms.ProductionMethods()
+	if len(pms) != 1 || pms[0].Key != "pm_muskets" {
+		t.Fatalf("unexpected production methods: %v", pms)
+	}
+	if len(pms[0].Inputs) != 2 || pms[0].Inputs[1].Good.Key != "coal" {
+		t.Errorf("unexpected inputs: %v", pms[0].Inputs)
+	}
+}
+
+func TestResolve_reverseLinks(t *testing.T) {
+	ix := resolve(t)
+
+	iron := ix.Goods["iron"]
+	if len(iron.ConsumedBy) != 2 || len(iron.ProducedBy) != 0 {
+		t.Errorf("expected iron to be consumed by 2 production methods and produced by none")
+	}
+	heavy := ix.BuildingGroups["bg_heavy_industry"]
+	if len(heavy.Buildings) != 2 {
+		t.Errorf("expected 2 buildings in bg_heavy_industry, actual: %d", len(heavy.Buildings))
+	}
+	if len(ix.BuildingGroups["bg_manufacturing"].Children) != 1 {
+		t.Errorf("expected bg_manufacturing to have 1 child")
+	}
+	muskets := ix.ProductionMethods["pm_muskets"]
+	if len(muskets.Groups) != 1 || muskets.Groups[0].Buildings[0].Key != "building_arms_industry" {
+		t.Errorf("expected pm_muskets to link back to building_arms_industry")
+	}
+}
+
+func TestBuildingsProducing(t *testing.T) {
+	ix := resolve(t)
+
+	arms := keys(ix.BuildingsProducing("small_arms"))
+	if len(arms) != 1 || arms[0] != "building_arms_industry" {
+		t.Errorf("unexpected buildings producing small_arms: %v", arms)
+	}
+	iron := keys(ix.BuildingsConsuming("iron"))
+	if len(iron) != 2 || iron[0] != "building_tooling_workshops" {
+		t.Errorf("unexpected buildings consuming iron: %v", iron)
+	}
+	if none := ix.BuildingsProducing("coal"); len(none) != 0 {
+		t.Errorf("expected no buildings producing coal, actual: %v", keys(none))
+	}
+}
+
+func TestResolve_dangling(t *testing.T) {
+	ix := resolve(t)
+
+	expected := []Dangling{
+		{From: "bg_dangling", Field: "parent_group", Ref: "bg_missing"},
+		{From: "pm_steel_tools", Field: "unlocking_technologies", Ref: "steelworking"},
+		{From: "pm_steel_tools", Field: "goods_input_steel_add", Ref: "steel"},
+		{From: "building_arms_industry", Field: "production_method_groups", Ref: "pmg_missing"},
+		{From: "building_broken", Field: "building_group", Ref: "bg_unknown"},
+	}
+	if len(ix.Dangling) != len(expected) {
+		t.Fatalf("expected %d dangling references, actual: %v", len(expected), ix.Errors())
+	}
+	for i, e := range expected {
+		a := ix.Dangling[i]
+		if a.From != e.From || a.Field != e.Field || a.Ref != e.Ref {
+			t.Errorf("expected: %v, actual: %v", e, a)
+		}
+	}
+
+	// a building without a group has no link, but is not a dangling reference either
+	if ungrouped := ix.Buildings["building_ungrouped"]; ungrouped == nil || ungrouped.Group != nil {
+		t.Errorf("expected building_ungrouped without a group, actual: %v", ungrouped)
+	}
+
+	// references are reported where they are written rather than at the definition making them,
+	// e.g. goods references at their modifier
+	sources := []struct {
+		file files.DataFile
+		line int
+	}{
+		{BuildingGroups, 10},
+		{ProductionMethods, 11},
+		{ProductionMethods, 14},
+		{Buildings, 10},
+		{Buildings, 14},
+	}
+	for i, e := range sources {
+		a := ix.Dangling[i].Source
+		if a.File != string(e.file) || a.Line() != e.line {
+			t.Errorf("expected: %s:%d, actual: %s", e.file, e.line, a)
+		}
+	}
+}
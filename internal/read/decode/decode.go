@@ -0,0 +1,402 @@
+package decode
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Unmarshaler is implemented by types which decode themselves from a syntax tree value
+type Unmarshaler interface {
+	UnmarshalPDX(val parser.Value) error
+}
+
+var (
+	unmarshalerType = reflect.TypeFor[Unmarshaler]()
+	valueType       = reflect.TypeFor[parser.Value]()
+	blockType       = reflect.TypeFor[*parser.Block]()
+	scalarType      = reflect.TypeFor[*parser.Scalar]()
+	fieldType       = reflect.TypeFor[*parser.Field]()
+	positionType    = reflect.TypeFor[files.Position]()
+)
+
+// Unmarshal decodes val into v, which must be a non-nil pointer.
+// Unknown fields are ignored; use a Decoder to report them.
+func Unmarshal(val parser.Value, v any) error {
+	return NewDecoder().Decode(val, v)
+}
+
+// UnmarshalFile parses the DataFile and decodes its top level into v
+func UnmarshalFile(df files.DataFile, v any) error {
+	file, err := parser.ParseFile(df)
+	if err != nil {
+		return err
+	}
+	err = Unmarshal(file.Block, v)
+	if err != nil {
+		return fmt.Errorf("%s:%w", df, err)
+	}
+	return nil
+}
+
+// UnmarshalSource parses the Source and decodes its top level into v
+func UnmarshalSource(src files.Source, v any) error {
+	file, err := parser.ParseSource(src)
+	if err != nil {
+		return err
+	}
+	err = Unmarshal(file.Block, v)
+	if err != nil {
+		return fmt.Errorf("%s:%w", src.Name(), err)
+	}
+	return nil
+}
+
+// UnmarshalDir decodes the top level of every file in the DataDir into v, in file order.
+// Maps accumulate entries across files, with later files overwriting earlier keys.
+func UnmarshalDir(d dirs.DataDir, v any) error {
+	dfs, err := d.Files()
+	if err != nil {
+		return err
+	}
+	for _, df := range dfs {
+		if err := UnmarshalFile(df, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Decoder decodes syntax tree values into Go values, using `pdx:"key"` struct tags.
+//
+// Supported targets are:
+//   - structs, decoded from blocks of fields
+//   - maps with string keys, decoded from blocks of fields
+//   - slices, decoded from arrays such as `{ a b c }` or from repeated keys
+//   - strings, bools (`yes`/`no`), and all int, uint and float kinds
+//   - pointers to any of the above
+//   - parser.Value, *parser.Block, *parser.Scalar and *parser.Field, which are kept as raw syntax tree nodes
+//   - types implementing Unmarshaler
+//
+// Struct fields can use the tag options `pdx:",key"` to receive the key the struct was assigned to,
+// and `pdx:",pos"` on a files.Position to receive the position of the value.
+type Decoder struct {
+	disallowUnknown bool
+	unknown         []*UnknownFieldError
+}
+
+func NewDecoder() *Decoder {
+	return &Decoder{}
+}
+
+// DisallowUnknownFields causes Decode to return an error for keys which do not match a struct field
+func (d *Decoder) DisallowUnknownFields() {
+	d.disallowUnknown = true
+}
+
+// Unknown returns every unknown field found by this Decoder so far, in the order they were found
+func (d *Decoder) Unknown() []*UnknownFieldError {
+	return d.unknown
+}
+
+// Decode decodes val into v, which must be a non-nil pointer
+func (d *Decoder) Decode(val parser.Value, v any) error {
+	return d.decode(val, "", v)
+}
+
+// DecodeField decodes the value of f into v, passing the field's key to any `pdx:",key"` struct field
+func (d *Decoder) DecodeField(f *parser.Field, v any) error {
+	return d.decode(f.Value, f.Name(), v)
+}
+
+func (d *Decoder) decode(val parser.Value, key string, v any) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Pointer || rv.IsNil() {
+		return fmt.Errorf("decode target must be a non-nil pointer, got %T", v)
+	}
+	// only unknown fields found by this call fail it, so a Decoder can be reused
+	seen := len(d.unknown)
+	err := d.decodeValue(val, key, rv.Elem())
+	if err == nil && d.disallowUnknown && len(d.unknown) > seen {
+		err = d.unknown[seen]
+	}
+	return err
+}
+
+// decodeValue decodes val into the settable rv. key is the field key val was assigned to, if any.
+func (d *Decoder) decodeValue(val parser.Value, key string, rv reflect.Value) error {
+	if ok, err := d.decodeRaw(val, rv); ok {
+		return err
+	}
+
+	if rv.CanAddr() && rv.Addr().Type().Implements(unmarshalerType) {
+		return rv.Addr().Interface().(Unmarshaler).UnmarshalPDX(val)
+	}
+
+	switch rv.Kind() {
+	case reflect.Pointer:
+		if rv.IsNil() {
+			rv.Set(reflect.New(rv.Type().Elem()))
+		}
+		return d.decodeValue(val, key, rv.Elem())
+	case reflect.Struct:
+		blk, err := asBlock(val, rv.Type())
+		if err != nil {
+			return err
+		}
+		return d.decodeStruct(blk, key, rv)
+	case reflect.Map:
+		blk, err := asBlock(val, rv.Type())
+		if err != nil {
+			return err
+		}
+		return d.decodeMap(blk, rv)
+	case reflect.Slice:
+		rv.SetLen(0)
+		return d.appendValue(val, rv)
+	default:
+		s, ok := val.(*parser.Scalar)
+		if !ok {
+			return newError(val.Start(), "cannot decode block into %s", rv.Type())
+		}
+		return decodeScalar(s, rv)
+	}
+}
+
+// decodeRaw sets syntax tree node targets. ok is false if rv is not a raw node type.
+func (d *Decoder) decodeRaw(val parser.Value, rv reflect.Value) (ok bool, err error) {
+	switch {
+	case rv.Type() == valueType || (rv.Kind() == reflect.Interface && rv.NumMethod() == 0):
+		rv.Set(reflect.ValueOf(val))
+	case rv.Type() == blockType:
+		blk, err := asBlock(val, blockType)
+		if err != nil {
+			return true, err
+		}
+		rv.Set(reflect.ValueOf(blk))
+	case rv.Type() == scalarType:
+		s, isScalar := val.(*parser.Scalar)
+		if !isScalar {
+			return true, newError(val.Start(), "cannot decode block into %s", rv.Type())
+		}
+		rv.Set(reflect.ValueOf(s))
+	default:
+		return false, nil
+	}
+	return true, nil
+}
+
+func asBlock(val parser.Value, typ reflect.Type) (*parser.Block, error) {
+	blk, ok := val.(*parser.Block)
+	if !ok {
+		return nil, newError(val.Start(), "cannot decode scalar %q into %s", val.(*parser.Scalar).Text(), typ)
+	}
+	return blk, nil
+}
+
+// appendValue decodes val as one or more elements of the slice rv.
+// Blocks without fields, such as `{ a b c }` or `{ { a } { b } }`, are treated as arrays of elements;
+// anything else is a single element, which allows repeated keys to be collected into a slice.
+func (d *Decoder) appendValue(val parser.Value, rv reflect.Value) error {
+	if blk, ok := val.(*parser.Block); ok && len(blk.Fields()) == 0 && rv.Type().Elem() != blockType {
+		for _, item := range blk.Values() {
+			if err := d.appendOne(item, rv); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	return d.appendOne(val, rv)
+}
+
+func (d *Decoder) appendOne(val parser.Value, rv reflect.Value) error {
+	elem := reflect.New(rv.Type().Elem()).Elem()
+	if err := d.decodeValue(val, "", elem); err != nil {
+		return err
+	}
+	rv.Set(reflect.Append(rv, elem))
+	return nil
+}
+
+// decodeFields decodes every field with the same key into rv.
+// Slices receive all the fields, and any other type receives the last one, as the game does.
+func (d *Decoder) decodeFields(fields []*parser.Field, rv reflect.Value) error {
+	switch {
+	case rv.Type() == fieldType:
+		rv.Set(reflect.ValueOf(fields[len(fields)-1]))
+		return nil
+	case rv.Kind() == reflect.Slice && rv.Type().Elem() == fieldType:
+		rv.Set(reflect.ValueOf(fields))
+		return nil
+	case rv.Kind() == reflect.Slice && !rv.Addr().Type().Implements(unmarshalerType):
+		rv.SetLen(0)
+		for _, f := range fields {
+			if err := d.appendValue(f.Value, rv); err != nil {
+				return err
+			}
+		}
+		return nil
+	default:
+		last := fields[len(fields)-1]
+		return d.decodeValue(last.Value, last.Name(), rv)
+	}
+}
+
+func (d *Decoder) decodeStruct(blk *parser.Block, key string, rv reflect.Value) error {
+	info := structInfoFor(rv.Type())
+	if info.keyField >= 0 {
+		rv.Field(info.keyField).SetString(key)
+	}
+	if info.posField >= 0 {
+		rv.Field(info.posField).Set(reflect.ValueOf(blk.Start()))
+	}
+
+	for _, item := range blk.Items {
+		if _, ok := item.(*parser.Field); !ok {
+			return newError(item.Start(), "unexpected value in %s; expected key = value", rv.Type())
+		}
+	}
+
+	for _, k := range blk.Keys() {
+		fields := blk.GetAll(k)
+		idx, ok := info.lookup(k)
+		if !ok {
+			d.unknown = append(d.unknown, &UnknownFieldError{Key: k, Pos: fields[0].Start(), Type: rv.Type()})
+			continue
+		}
+		if err := d.decodeFields(fields, rv.Field(idx)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *Decoder) decodeMap(blk *parser.Block, rv reflect.Value) error {
+	if rv.Type().Key().Kind() != reflect.String {
+		return newError(blk.Start(), "cannot decode into %s; map keys must be strings", rv.Type())
+	}
+	if rv.IsNil() {
+		rv.Set(reflect.MakeMap(rv.Type()))
+	}
+
+	for _, item := range blk.Items {
+		if _, ok := item.(*parser.Field); !ok {
+			return newError(item.Start(), "unexpected value in %s; expected key = value", rv.Type())
+		}
+	}
+
+	for _, k := range blk.Keys() {
+		elem := reflect.New(rv.Type().Elem()).Elem()
+		if err := d.decodeFields(blk.GetAll(k), elem); err != nil {
+			return err
+		}
+		rv.SetMapIndex(reflect.ValueOf(k).Convert(rv.Type().Key()), elem)
+	}
+	return nil
+}
+
+func decodeScalar(s *parser.Scalar, rv reflect.Value) error {
+	text := s.Text()
+	switch rv.Kind() {
+	case reflect.String:
+		rv.SetString(text)
+	case reflect.Bool:
+		switch text {
+		case "yes":
+			rv.SetBool(true)
+		case "no":
+			rv.SetBool(false)
+		default:
+			return newError(s.Start(), "cannot decode %q into bool; expected yes or no", text)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(text, 10, rv.Type().Bits())
+		if err != nil {
+			return newError(s.Start(), "cannot decode %q into %s", text, rv.Type())
+		}
+		rv.SetInt(n)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(text, 10, rv.Type().Bits())
+		if err != nil {
+			return newError(s.Start(), "cannot decode %q into %s", text, rv.Type())
+		}
+		rv.SetUint(n)
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(text, rv.Type().Bits())
+		if err != nil {
+			return newError(s.Start(), "cannot decode %q into %s", text, rv.Type())
+		}
+		rv.SetFloat(n)
+	default:
+		return newError(s.Start(), "cannot decode %q into unsupported type %s", text, rv.Type())
+	}
+	return nil
+}
+
+// structInfo is the mapping of keys to field indexes for a struct type
+type structInfo struct {
+	fields   map[string]int
+	keyField int
+	posField int
+}
+
+func (si structInfo) lookup(key string) (int, bool) {
+	idx, ok := si.fields[key]
+	if !ok {
+		idx, ok = si.fields[strings.ToLower(key)]
+	}
+	return idx, ok
+}
+
+func structInfoFor(typ reflect.Type) structInfo {
+	info := structInfo{fields: map[string]int{}, keyField: -1, posField: -1}
+	for i := range typ.NumField() {
+		sf := typ.Field(i)
+		if !sf.IsExported() {
+			continue
+		}
+		name, opts, _ := strings.Cut(sf.Tag.Get("pdx"), ",")
+		switch {
+		case name == "-":
+			continue
+		case opts == "key" && sf.Type.Kind() == reflect.String:
+			info.keyField = i
+		case opts == "pos" && sf.Type == positionType:
+			info.posField = i
+		case name != "":
+			info.fields[name] = i
+		default:
+			info.fields[strings.ToLower(sf.Name)] = i
+		}
+	}
+	return info
+}
+
+// Error is a decoding error at a position in a file
+type Error struct {
+	Pos files.Position
+	Msg string
+}
+
+func newError(pos files.Position, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Pos.Line(), e.Pos.Col(), e.Msg)
+}
+
+// UnknownFieldError is a key which does not match any field of the struct it was decoded into
+type UnknownFieldError struct {
+	Key  string
+	Pos  files.Position
+	Type reflect.Type
+}
+
+func (e *UnknownFieldError) Error() string {
+	return fmt.Sprintf("%d:%d: unknown field %q in %s", e.Pos.Line(), e.Pos.Col(), e.Key, e.Type)
+}
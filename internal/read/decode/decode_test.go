@@ -0,0 +1,238 @@
+package decode
+
+import (
+	"errors"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+const (
+	Goods    files.DataFile = "testdata/goods.txt"
+	Building files.DataFile = "testdata/building.txt"
+	BadInt   files.DataFile = "testdata/bad-int.txt"
+	BadBool  files.DataFile = "testdata/bad-bool.txt"
+)
+
+type good struct {
+	Key            string         `pdx:",key"`
+	Pos            files.Position `pdx:",pos"`
+	Texture        string         `pdx:"texture"`
+	Cost           float64        `pdx:"cost"`
+	Category       string         `pdx:"category"`
+	PrestigeFactor int            `pdx:"prestige_factor"`
+	TradedQuantity float32        `pdx:"traded_quantity"`
+	Local          bool           `pdx:"local"`
+}
+
+type named struct {
+	Name  string
+	Value int
+}
+
+// modifiers collects every modifier name, to test Unmarshaler
+type modifiers []string
+
+func (m *modifiers) UnmarshalPDX(val parser.Value) error {
+	var n named
+	if err := Unmarshal(val, &n); err != nil {
+		return err
+	}
+	*m = append(*m, n.Name)
+	return nil
+}
+
+type building struct {
+	BuildingGroup          string        `pdx:"building_group"`
+	LevelsPerMesh          *int          `pdx:"levels_per_mesh"`
+	ProductionMethodGroups []string      `pdx:"production_method_groups"`
+	UnlockingTechnologies  []string      `pdx:"unlocking_technologies"`
+	Modifier               []named       `pdx:"modifier"`
+	Possible               *parser.Block `pdx:"possible"`
+	Color                  parser.Value  `pdx:"color"`
+}
+
+func TestUnmarshalFile_goodsMap(t *testing.T) {
+	var goods map[string]good
+	err := UnmarshalFile(Goods, &goods)
+	if err != nil {
+		t.Fatalf("UnmarshalFile returned unexpected error: %v", err)
+	}
+	if len(goods) != 4 {
+		t.Fatalf("expected 4 goods, actual: %d", len(goods))
+	}
+
+	ammunition := goods["ammunition"]
+	if ammunition.Cost != 50 {
+		t.Errorf("expected: %v, actual: %v", 50, ammunition.Cost)
+	}
+	if ammunition.Category != "military" {
+		t.Errorf("expected: %s, actual: %s", "military", ammunition.Category)
+	}
+	if ammunition.Texture != "gfx/interface/icons/goods_icons/ammunition.dds" {
+		t.Errorf("unexpected texture: %s", ammunition.Texture)
+	}
+	if ammunition.PrestigeFactor != 5 {
+		t.Errorf("expected: %v, actual: %v", 5, ammunition.PrestigeFactor)
+	}
+	if goods["artillery"].TradedQuantity != 3.5 {
+		t.Errorf("expected: %v, actual: %v", 3.5, goods["artillery"].TradedQuantity)
+	}
+}
+
+func TestUnmarshalFile_keyAndPos(t *testing.T) {
+	var goods map[string]good
+	err := UnmarshalFile(Goods, &goods)
+	if err != nil {
+		t.Fatalf("UnmarshalFile returned unexpected error: %v", err)
+	}
+
+	smallArms := goods["small_arms"]
+	if smallArms.Key != "small_arms" {
+		t.Errorf("expected: %s, actual: %s", "small_arms", smallArms.Key)
+	}
+	if smallArms.Pos.Line() <= goods["ammunition"].Pos.Line() {
+		t.Errorf("expected small_arms after ammunition; actual lines %d and %d", smallArms.Pos.Line(), goods["ammunition"].Pos.Line())
+	}
+}
+
+func TestUnmarshalFile_yesNo(t *testing.T) {
+	var goods map[string]good
+	err := UnmarshalFile(Goods, &goods)
+	if err != nil {
+		t.Fatalf("UnmarshalFile returned unexpected error: %v", err)
+	}
+	if !goods["services"].Local {
+		t.Errorf("expected services to be local")
+	}
+	if goods["ammunition"].Local {
+		t.Errorf("expected ammunition not to be local")
+	}
+}
+
+func TestUnmarshalFile_building(t *testing.T) {
+	var buildings map[string]building
+	err := UnmarshalFile(Building, &buildings)
+	if err != nil {
+		t.Fatalf("UnmarshalFile returned unexpected error: %v", err)
+	}
+	b := buildings["building_food_industry"]
+
+	if b.LevelsPerMesh == nil || *b.LevelsPerMesh != 5 {
+		t.Errorf("expected levels_per_mesh to be 5, actual: %v", b.LevelsPerMesh)
+	}
+
+	// repeated keys are merged into one slice
+	expectedPMGs := []string{"pmg_base_building_food_industry", "pmg_canning", "pmg_distillery"}
+	if len(b.ProductionMethodGroups) != len(expectedPMGs) {
+		t.Fatalf("expected: %v, actual: %v", expectedPMGs, b.ProductionMethodGroups)
+	}
+	for i, pmg := range expectedPMGs {
+		if b.ProductionMethodGroups[i] != pmg {
+			t.Errorf("expected: %s, actual: %s", pmg, b.ProductionMethodGroups[i])
+		}
+	}
+
+	if len(b.Modifier) != 2 || b.Modifier[1].Name != "b" || b.Modifier[1].Value != 2 {
+		t.Errorf("expected two modifiers, actual: %v", b.Modifier)
+	}
+
+	if b.Possible == nil || len(b.Possible.Fields()) != 1 {
+		t.Errorf("expected possible to be kept as a raw block with one field")
+	}
+	if color, ok := b.Color.(*parser.Block); !ok || color.Tag != "rgb" {
+		t.Errorf("expected color to be kept as a raw rgb block, actual: %v", b.Color)
+	}
+}
+
+func TestUnmarshal_unmarshaler(t *testing.T) {
+	file, err := parser.ParseFile(Building)
+	if err != nil {
+		t.Fatalf("ParseFile returned unexpected error: %v", err)
+	}
+	var b struct {
+		Modifier modifiers `pdx:"modifier"`
+	}
+	blk, _ := file.Get("building_food_industry")
+	err = Unmarshal(blk.Value, &b)
+	if err != nil {
+		t.Fatalf("Unmarshal returned unexpected error: %v", err)
+	}
+	if len(b.Modifier) != 1 || b.Modifier[0] != "b" {
+		t.Errorf("Unmarshaler should be called with the last repeated value; actual: %v", b.Modifier)
+	}
+}
+
+func TestDecoder_unknownFields(t *testing.T) {
+	file, err := parser.ParseFile(Goods)
+	if err != nil {
+		t.Fatalf("ParseFile returned unexpected error: %v", err)
+	}
+
+	var goods map[string]struct {
+		Cost float64 `pdx:"cost"`
+	}
+	dec := NewDecoder()
+	err = dec.Decode(file.Block, &goods)
+	if err != nil {
+		t.Fatalf("Decode returned unexpected error: %v", err)
+	}
+	unknown := dec.Unknown()
+	if len(unknown) == 0 {
+		t.Fatalf("expected unknown fields to be reported")
+	}
+	if unknown[0].Key != "texture" {
+		t.Errorf("expected: %s, actual: %s", "texture", unknown[0].Key)
+	}
+
+	strict := NewDecoder()
+	strict.DisallowUnknownFields()
+	err = strict.Decode(file.Block, &goods)
+	var unknownErr *UnknownFieldError
+	if !errors.As(err, &unknownErr) {
+		t.Errorf("expected an unknown field error, actual: %v", err)
+	}
+}
+
+func TestDecoder_unknownFieldsAreNotCarriedOver(t *testing.T) {
+	file, err := parser.ParseFile(Goods)
+	if err != nil {
+		t.Fatalf("ParseFile returned unexpected error: %v", err)
+	}
+	ammunition, _ := file.Get("ammunition")
+
+	strict := NewDecoder()
+	strict.DisallowUnknownFields()
+	var cost struct {
+		Cost float64 `pdx:"cost"`
+	}
+	if err := strict.Decode(ammunition.Value, &cost); err == nil {
+		t.Fatalf("expected an unknown field error")
+	}
+
+	var raw map[string]parser.Value
+	if err := strict.Decode(ammunition.Value, &raw); err != nil {
+		t.Errorf("unknown fields from an earlier Decode should not fail a clean input, actual: %v", err)
+	}
+}
+
+func TestUnmarshalFile_invalidScalars(t *testing.T) {
+	var goods map[string]good
+	for _, df := range []files.DataFile{BadInt, BadBool} {
+		err := UnmarshalFile(df, &goods)
+		var decErr *Error
+		if !errors.As(err, &decErr) {
+			t.Errorf("%s: expected a decode error, actual: %v", df, err)
+		} else if decErr.Pos.Line() != 2 {
+			t.Errorf("%s: expected error on line %d, actual: %d", df, 2, decErr.Pos.Line())
+		}
+	}
+}
+
+func TestDecode_nonPointerReturnsError(t *testing.T) {
+	var goods map[string]good
+	err := Unmarshal(&parser.Block{}, goods)
+	if err == nil {
+		t.Errorf("Unmarshal did not return an error for a non-pointer target")
+	}
+}
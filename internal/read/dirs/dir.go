@@ -3,7 +3,6 @@ package dirs
 import (
 	"os"
 	"path/filepath"
-	"strings"
 	"vic3-data-reader/internal/env"
 	"vic3-data-reader/internal/read/files"
 )
@@ -19,13 +18,71 @@ func dataRootPath() (string, error) {
 
 type DataDir string
 
+// Data dirs are relative to game/common
 const (
-	BuildingGroups         DataDir = "building_groups"
-	Buildings              DataDir = "buildings"
-	Goods                  DataDir = "goods"
-	ProductionMethodGroups DataDir = "production_method_groups"
-	ProductionMethods      DataDir = "production_methods"
-	Technologies           DataDir = "technology/technologies"
+	AIStrategies            DataDir = "ai_strategies"
+	Amendments              DataDir = "amendments"
+	BattleConditions        DataDir = "battle_conditions"
+	BuildingGroups          DataDir = "building_groups"
+	Buildings               DataDir = "buildings"
+	BuyPackages             DataDir = "buy_packages"
+	CharacterInteractions   DataDir = "character_interactions"
+	CharacterTemplates      DataDir = "character_templates"
+	CharacterTraits         DataDir = "character_traits"
+	CoatOfArms              DataDir = "coat_of_arms/coat_of_arms"
+	CombatUnitGroups        DataDir = "combat_unit_groups"
+	CombatUnitTypes         DataDir = "combat_unit_types"
+	CommanderOrders         DataDir = "commander_orders"
+	CommanderRanks          DataDir = "commander_ranks"
+	Companies               DataDir = "company_types"
+	CountryCreation         DataDir = "country_creation"
+	CountryDefinitions      DataDir = "country_definitions"
+	CountryFormation        DataDir = "country_formation"
+	CountryRanks            DataDir = "country_ranks"
+	CountryTypes            DataDir = "country_types"
+	Cultures                DataDir = "cultures"
+	Decisions               DataDir = "decisions"
+	Decrees                 DataDir = "decrees"
+	Defines                 DataDir = "defines"
+	DiplomaticActions       DataDir = "diplomatic_actions"
+	DiplomaticPlays         DataDir = "diplomatic_plays"
+	DiscriminationTraits    DataDir = "discrimination_traits"
+	DynamicCountryNames     DataDir = "dynamic_country_names"
+	FlagDefinitions         DataDir = "flag_definitions"
+	GameRules               DataDir = "game_rules"
+	Goods                   DataDir = "goods"
+	GovernmentTypes         DataDir = "government_types"
+	History                 DataDir = "history"
+	Ideologies              DataDir = "ideologies"
+	Institutions            DataDir = "institutions"
+	InterestGroupTraits     DataDir = "interest_group_traits"
+	InterestGroups          DataDir = "interest_groups"
+	JournalEntries          DataDir = "journal_entries"
+	LawGroups               DataDir = "law_groups"
+	Laws                    DataDir = "laws"
+	LegitimacyLevels        DataDir = "legitimacy_levels"
+	MobilizationOptions     DataDir = "mobilization_options"
+	ModifierTypeDefinitions DataDir = "modifier_type_definitions"
+	Modifiers               DataDir = "modifiers"
+	NamedColors             DataDir = "named_colors"
+	OnActions               DataDir = "on_actions"
+	PopNeeds                DataDir = "pop_needs"
+	PopTypes                DataDir = "pop_types"
+	PowerBlocPrinciples     DataDir = "power_bloc_principles"
+	PrestigeGoods           DataDir = "prestige_goods"
+	ProductionMethodGroups  DataDir = "production_method_groups"
+	ProductionMethods       DataDir = "production_methods"
+	ProposalTypes           DataDir = "proposal_types"
+	Religions               DataDir = "religions"
+	ScriptValues            DataDir = "script_values"
+	ScriptedEffects         DataDir = "scripted_effects"
+	ScriptedTriggers        DataDir = "scripted_triggers"
+	StateTraits             DataDir = "state_traits"
+	StrategicRegions        DataDir = "strategic_regions"
+	SubjectTypes            DataDir = "subject_types"
+	Technologies            DataDir = "technology/technologies"
+	TechnologyEras          DataDir = "technology/eras"
+	WarGoalTypes            DataDir = "war_goal_types"
 )
 
 func (d DataDir) DirPath() (string, error) {
@@ -37,7 +94,9 @@ func (d DataDir) DirPath() (string, error) {
 	return fp, err
 }
 
-func (d DataDir) Files() ([]files.DataFile, error) {
+// Files lists the data files in the DataDir, in the order the game loads them.
+// By default only .txt files directly within the dir are listed, skipping dummy files.
+func (d DataDir) Files(opts ...ListOption) ([]files.DataFile, error) {
 	var dfs []files.DataFile
 	var err error
 
@@ -46,27 +105,14 @@ func (d DataDir) Files() ([]files.DataFile, error) {
 	if err != nil {
 		return dfs, err
 	}
-	fps, err := os.ReadDir(dir)
+	names, err := dataFileNames(os.DirFS(dir), ".", opts)
 	if err != nil {
 		return dfs, err
 	}
 
-	// filter files in dir
-	isTxt := func(entry os.DirEntry) bool {
-		return strings.HasSuffix(entry.Name(), ".txt")
-	}
-	isDummy := func(entry os.DirEntry) bool {
-		return strings.Contains(entry.Name(), "dummy")
-	}
-	isValid := func(entry os.DirEntry) bool {
-		return !entry.IsDir() && isTxt(entry) && !isDummy(entry)
-	}
-
-	for _, entry := range fps {
-		if isValid(entry) {
-			fp := filepath.Join(dir, entry.Name())
-			dfs = append(dfs, files.DataFile(fp))
-		}
+	for _, name := range names {
+		fp := filepath.Join(dir, filepath.FromSlash(name))
+		dfs = append(dfs, files.DataFile(fp))
 	}
 
 	return dfs, err
@@ -105,6 +105,16 @@ func TestDirPath_Technologies(t *testing.T) {
 	}
 }
 
+func TestDirPath_TechnologyEras(t *testing.T) {
+	expected := expectedDir(".local/share/Steam/steamapps/common/Victoria 3/game/common/technology/eras")
+	actual, err := TechnologyEras.DirPath()
+	if err != nil {
+		t.Error("unexpected error: ", err)
+	} else if expected != actual {
+		t.Errorf("expected: %s, actual: %s", expected, actual)
+	}
+}
+
 const (
 	TestEmptyDir      DataDir = "empty"
 	TestDummyOnlyDir  DataDir = "dummy-only"
@@ -0,0 +1,66 @@
+package dirs
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"vic3-data-reader/internal/env"
+	"vic3-data-reader/internal/read/files"
+)
+
+// commonDir is the directory within an install which holds every DataDir
+const commonDir = "game/common"
+
+// GameInstall is a Victoria 3 install, i.e. a filesystem containing game/common.
+// Several installs can be opened side by side, since nothing is read from the process environment.
+type GameInstall struct {
+	fsys fs.FS
+}
+
+// NewGameInstall uses fsys as the root of the install, e.g. an os.DirFS, a zip.Reader, or an fstest.MapFS
+func NewGameInstall(fsys fs.FS) GameInstall {
+	return GameInstall{fsys: fsys}
+}
+
+// OpenGameInstall uses the directory on disk as the root of the install
+func OpenGameInstall(dir string) GameInstall {
+	return NewGameInstall(os.DirFS(dir))
+}
+
+// DefaultGameInstall opens the install found by the VIC3_DIR environment variable, or its default
+func DefaultGameInstall() (GameInstall, error) {
+	dir, err := env.Vic3Dir.GetValue()
+	if err != nil {
+		return GameInstall{}, err
+	}
+	return OpenGameInstall(dir), nil
+}
+
+func (g GameInstall) FS() fs.FS {
+	return g.fsys
+}
+
+// DirPath is the slash separated path of the DataDir within the install
+func (g GameInstall) DirPath(d DataDir) string {
+	return path.Join(commonDir, string(d))
+}
+
+// Files returns the data files in the DataDir, in the order the game loads them.
+// By default only .txt files directly within the dir are listed, skipping dummy files.
+func (g GameInstall) Files(d DataDir, opts ...ListOption) ([]files.FSFile, error) {
+	return g.files(g.DirPath(d), opts)
+}
+
+// files lists the files within the slash separated dir of the install
+func (g GameInstall) files(dir string, opts []ListOption) ([]files.FSFile, error) {
+	names, err := dataFileNames(g.fsys, dir, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	srcs := make([]files.FSFile, len(names))
+	for i, name := range names {
+		srcs[i] = files.FSFile{FS: g.fsys, Path: path.Join(dir, name)}
+	}
+	return srcs, nil
+}
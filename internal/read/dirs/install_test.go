@@ -0,0 +1,250 @@
+package dirs
+
+import (
+	"path/filepath"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+const MockVic3Dir = "testdata/mockVic3Dir"
+
+func TestGameInstall_DirPath(t *testing.T) {
+	g := OpenGameInstall(MockVic3Dir)
+	expected := "game/common/technology/technologies"
+	if actual := g.DirPath(Technologies); actual != expected {
+		t.Errorf("expected: %s, actual: %s", expected, actual)
+	}
+}
+
+func TestGameInstall_Files_dirFS(t *testing.T) {
+	g := OpenGameInstall(MockVic3Dir)
+	tests := map[DataDir][]string{
+		TestDummyOnlyDir:  nil,
+		TestReadmeOnlyDir: nil,
+		TestSingleFileDir: {"game/common/single-file/00_actual.txt"},
+		TestSmokeDir:      {"game/common/smoke/01_actual.txt"},
+	}
+	for d, expected := range tests {
+		srcs, err := g.Files(d)
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", d, err)
+			continue
+		}
+		if len(srcs) != len(expected) {
+			t.Errorf("%s: expected %d files, actual: %d", d, len(expected), len(srcs))
+			continue
+		}
+		for i, src := range srcs {
+			if src.Path != expected[i] {
+				t.Errorf("%s: expected: %s, actual: %s", d, expected[i], src.Path)
+			}
+		}
+	}
+}
+
+func TestGameInstall_Files_errOnMissingDir(t *testing.T) {
+	g := OpenGameInstall(MockVic3Dir)
+	_, err := g.Files("DOES-NOT-EXIST")
+	if err == nil {
+		t.Errorf("Files did not return an error for a missing dir")
+	}
+}
+
+func TestGameInstall_sideBySide(t *testing.T) {
+	base := NewGameInstall(fstest.MapFS{
+		"game/common/goods/00_goods.txt": &fstest.MapFile{Data: []byte("ammunition = { cost = 50 }")},
+		"game/common/goods/01_goods.txt": &fstest.MapFile{Data: []byte("small_arms = { cost = 60 }")},
+	})
+	other := NewGameInstall(fstest.MapFS{
+		"game/common/goods/00_other.txt": &fstest.MapFile{Data: []byte("grain = { cost = 20 }")},
+	})
+
+	baseFiles, err := base.Files(Goods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	otherFiles, err := other.Files(Goods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(baseFiles) != 2 || len(otherFiles) != 1 {
+		t.Fatalf("expected 2 and 1 files, actual: %d and %d", len(baseFiles), len(otherFiles))
+	}
+	if baseFiles[0].Base() != "00_goods.txt" || baseFiles[1].Base() != "01_goods.txt" {
+		t.Errorf("expected files in lexicographic order, actual: %v", baseFiles)
+	}
+
+	rdr, err := otherFiles[0].NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer func() { _ = rdr.Close() }()
+	if ch, _ := rdr.Next(); ch != 'g' {
+		t.Errorf("expected: %q, actual: %q", 'g', ch)
+	}
+}
+
+const TestNestedDir DataDir = "nested"
+
+func TestGameInstall_Files_nestedIsNotRecursiveByDefault(t *testing.T) {
+	srcs, err := OpenGameInstall(MockVic3Dir).Files(TestNestedDir)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(srcs) != 1 || srcs[0].Path != "game/common/nested/01_top.txt" {
+		t.Errorf("expected only the top level file, actual: %v", srcs)
+	}
+}
+
+func TestGameInstall_Files_recursive(t *testing.T) {
+	srcs, err := OpenGameInstall(MockVic3Dir).Files(TestNestedDir, Recursive())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// ordered by file name, wherever the file is
+	expected := []string{
+		"game/common/nested/a/00_deep.txt",
+		"game/common/nested/01_top.txt",
+		"game/common/nested/a/b/02_deeper.txt",
+	}
+	if len(srcs) != len(expected) {
+		t.Fatalf("expected %d files, actual: %v", len(expected), srcs)
+	}
+	for i, src := range srcs {
+		if src.Path != expected[i] {
+			t.Errorf("expected: %s, actual: %s", expected[i], src.Path)
+		}
+	}
+}
+
+func TestGameInstall_Files_sameNameOrderedByPath(t *testing.T) {
+	g := NewGameInstall(fstest.MapFS{
+		"game/common/goods/b/00_goods.txt": &fstest.MapFile{},
+		"game/common/goods/a/00_goods.txt": &fstest.MapFile{},
+		"game/common/goods/00_goods.txt":   &fstest.MapFile{},
+	})
+	srcs, err := g.Files(Goods, Recursive())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"game/common/goods/00_goods.txt", "game/common/goods/a/00_goods.txt", "game/common/goods/b/00_goods.txt"}
+	for i, src := range srcs {
+		if src.Path != expected[i] {
+			t.Errorf("expected: %s, actual: %s", expected[i], src.Path)
+		}
+	}
+}
+
+func TestGameInstall_Files_includeExclude(t *testing.T) {
+	g := OpenGameInstall(MockVic3Dir)
+
+	readmes, err := g.Files(TestSmokeDir, Recursive(), Include(func(rel string) bool { return strings.HasSuffix(rel, ".md") }))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(readmes) != 2 {
+		t.Errorf("expected 2 readme files, actual: %v", readmes)
+	}
+
+	withDummies, err := g.Files(TestSmokeDir, Exclude())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(withDummies) != 2 || withDummies[0].Base() != "00_dummy.txt" {
+		t.Errorf("expected dummy file to be included, actual: %v", withDummies)
+	}
+}
+
+func TestFiles_recursive(t *testing.T) {
+	test := func() {
+		dfs, err := TestNestedDir.Files(Recursive())
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(dfs) != 3 {
+			t.Fatalf("expected 3 files, actual: %d", len(dfs))
+		}
+		expected := filepath.Join("nested", "a", "b", "02_deeper.txt")
+		if !strings.HasSuffix(string(dfs[2]), expected) {
+			t.Errorf("expected: %s, actual: %s", expected, dfs[2])
+		}
+	}
+	filesTestHelper(t, test)
+}
+
+func TestStack_Files(t *testing.T) {
+	game := NewGameInstall(fstest.MapFS{
+		"game/common/goods/00_goods.txt":        &fstest.MapFile{},
+		"game/common/goods/01_goods.txt":        &fstest.MapFile{},
+		"game/common/buildings/00_a.txt":        &fstest.MapFile{},
+		"game/common/buildings/nested/00_b.txt": &fstest.MapFile{},
+	})
+	mod := Layer{
+		Name: "mod",
+		FS: fstest.MapFS{
+			"common/goods/01_goods.txt":   &fstest.MapFile{},
+			"common/buildings/00_mod.txt": &fstest.MapFile{},
+		},
+		ReplacePaths: []string{"common/buildings"},
+	}
+	s := NewStack(game.Layer(), mod)
+
+	goods, err := s.Files(Goods)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(goods) != 2 || goods[0].Origin() != "game" || goods[1].Origin() != "mod" {
+		t.Errorf("expected the mod's 01_goods.txt to replace the game's, actual: %v", goods)
+	}
+
+	// replace_path only hides files directly within the directory
+	buildings, err := s.Files(Buildings, Recursive())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []string{"common/buildings/nested/00_b.txt", "common/buildings/00_mod.txt"}
+	if len(buildings) != len(expected) {
+		t.Fatalf("expected %d files, actual: %v", len(expected), buildings)
+	}
+	for i, src := range buildings {
+		if src.Path != expected[i] {
+			t.Errorf("expected: %s, actual: %s", expected[i], src.Path)
+		}
+	}
+
+	if missing, err := s.Files(PopTypes); err != nil || len(missing) != 0 {
+		t.Errorf("expected no files and no error for a dir no layer has, actual: %v, %v", missing, err)
+	}
+}
+
+func TestLocalizationFiles(t *testing.T) {
+	game := NewGameInstall(fstest.MapFS{
+		"game/localization/english/goods_l_english.yml":         &fstest.MapFile{},
+		"game/localization/english/replace/goods_l_english.yml": &fstest.MapFile{},
+		"game/localization/english/readme.txt":                  &fstest.MapFile{},
+		"game/localization/french/goods_l_french.yml":           &fstest.MapFile{},
+	})
+	english, err := game.LocalizationFiles("english")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(english) != 2 || english[1].Path != "game/localization/english/replace/goods_l_english.yml" {
+		t.Errorf("unexpected files: %v", english)
+	}
+	if missing, err := game.LocalizationFiles("german"); err != nil || len(missing) != 0 {
+		t.Errorf("expected no files and no error for a missing language, actual: %v, %v", missing, err)
+	}
+
+	mod := Layer{Name: "mod", FS: fstest.MapFS{
+		"localization/english/goods_l_english.yml": &fstest.MapFile{},
+	}}
+	stacked, err := NewStack(game.Layer(), mod).LocalizationFiles("english")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(stacked) != 2 || stacked[0].Origin() != "mod" || stacked[1].Origin() != "game" {
+		t.Errorf("expected the mod's file to replace the game's, actual: %v", stacked)
+	}
+}
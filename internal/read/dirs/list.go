@@ -0,0 +1,122 @@
+package dirs
+
+import (
+	"io/fs"
+	"path"
+	"sort"
+	"strings"
+)
+
+// Filter reports whether a file matches, given its slash separated path relative to the DataDir
+type Filter func(rel string) bool
+
+// IsTxt matches script files
+func IsTxt(rel string) bool {
+	return strings.HasSuffix(rel, ".txt")
+}
+
+// IsDummy matches the placeholder files the game ships in otherwise empty directories
+func IsDummy(rel string) bool {
+	return strings.Contains(path.Base(rel), "dummy")
+}
+
+// listing is the configuration for enumerating the files of a DataDir
+type listing struct {
+	recursive bool
+	include   []Filter
+	exclude   []Filter
+}
+
+// ListOption configures which files of a DataDir are listed
+type ListOption func(*listing)
+
+// Recursive also lists files in subdirectories, at any depth
+func Recursive() ListOption {
+	return func(l *listing) {
+		l.recursive = true
+	}
+}
+
+// Include lists only files matching at least one of the filters, replacing the default of IsTxt
+func Include(filters ...Filter) ListOption {
+	return func(l *listing) {
+		l.include = filters
+	}
+}
+
+// Exclude skips files matching any of the filters, replacing the default of IsDummy
+func Exclude(filters ...Filter) ListOption {
+	return func(l *listing) {
+		l.exclude = filters
+	}
+}
+
+func newListing(opts []ListOption) *listing {
+	l := &listing{include: []Filter{IsTxt}, exclude: []Filter{IsDummy}}
+	for _, opt := range opts {
+		opt(l)
+	}
+	return l
+}
+
+func (l *listing) matches(rel string) bool {
+	for _, f := range l.exclude {
+		if f(rel) {
+			return false
+		}
+	}
+	for _, f := range l.include {
+		if f(rel) {
+			return true
+		}
+	}
+	return false
+}
+
+// dataFileNames returns the paths of the data files within dir, relative to dir.
+//
+// Files are in the order the game loads them: by file name, regardless of which subdirectory they are in,
+// so a file can override definitions from any file whose name sorts before it.
+// Files with the same name are ordered by their full path.
+func dataFileNames(fsys fs.FS, dir string, opts []ListOption) ([]string, error) {
+	l := newListing(opts)
+
+	var names []string
+	err := fs.WalkDir(fsys, dir, func(p string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if p != dir && !l.recursive {
+				return fs.SkipDir
+			}
+			return nil
+		}
+
+		rel := strings.TrimPrefix(p, dir+"/")
+		if dir == "." {
+			rel = p
+		}
+		if l.matches(rel) {
+			names = append(names, rel)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(names, func(i, j int) bool {
+		return loadsBefore(names[i], names[j])
+	})
+	return names, nil
+}
+
+// loadsBefore orders files by name, then by full path for files with the same name
+func loadsBefore(a, b string) bool {
+	ba, bb := path.Base(a), path.Base(b)
+	if ba != bb {
+		return ba < bb
+	}
+	return a < b
+}
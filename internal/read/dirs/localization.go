@@ -0,0 +1,45 @@
+package dirs
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"strings"
+	"vic3-data-reader/internal/read/files"
+)
+
+// localizationDir holds a directory of .yml files per language, alongside common
+const localizationDir = "localization"
+
+// IsLocalization matches the localization files of a language, which are named like goods_l_english.yml
+func IsLocalization(language string) Filter {
+	suffix := "_l_" + language + ".yml"
+	return func(rel string) bool {
+		return strings.HasSuffix(rel, suffix)
+	}
+}
+
+func localizationOptions(language string) []ListOption {
+	return []ListOption{Recursive(), Include(IsLocalization(language)), Exclude()}
+}
+
+// LocalizationDirPath is the slash separated path of the language's localization within the install
+func (g GameInstall) LocalizationDirPath(language string) string {
+	return path.Join("game", localizationDir, language)
+}
+
+// LocalizationFiles returns the localization files of the language, including those in subdirectories such as replace/.
+// An install without the language has no files for it, as with a Stack.
+func (g GameInstall) LocalizationFiles(language string) ([]files.FSFile, error) {
+	srcs, err := g.files(g.LocalizationDirPath(language), localizationOptions(language))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+	return srcs, err
+}
+
+// LocalizationFiles returns the merged localization files of the language.
+// As with data files, a file hides any file with the same path in an earlier layer.
+func (s *Stack) LocalizationFiles(language string) ([]files.FSFile, error) {
+	return s.files(path.Join(localizationDir, language), localizationOptions(language))
+}
@@ -0,0 +1,106 @@
+package dirs
+
+// Info describes what a DataDir contains
+type Info struct {
+	Dir DataDir
+	// Entity is the kind of definition each top level key in the dir's files is, e.g. "good"
+	Entity      string
+	Description string
+}
+
+// registry lists every known DataDir, in alphabetical order of constant name
+var registry = []Info{
+	{Dir: AIStrategies, Entity: "AI strategy", Description: "country AI strategies and their weights"},
+	{Dir: Amendments, Entity: "amendment", Description: "amendments which modify enacted laws"},
+	{Dir: BattleConditions, Entity: "battle condition", Description: "random conditions applied to battles"},
+	{Dir: BuildingGroups, Entity: "building group", Description: "hierarchy of building groups, with shared economy settings"},
+	{Dir: Buildings, Entity: "building", Description: "buildings, and the production method groups they use"},
+	{Dir: BuyPackages, Entity: "buy package", Description: "goods demanded by pops at each wealth level"},
+	{Dir: CharacterInteractions, Entity: "character interaction", Description: "player actions targeting characters"},
+	{Dir: CharacterTemplates, Entity: "character template", Description: "predefined historical characters"},
+	{Dir: CharacterTraits, Entity: "character trait", Description: "traits characters can have"},
+	{Dir: CoatOfArms, Entity: "coat of arms", Description: "country and title coats of arms"},
+	{Dir: CombatUnitGroups, Entity: "combat unit group", Description: "groups of combat unit types, e.g. infantry"},
+	{Dir: CombatUnitTypes, Entity: "combat unit type", Description: "army and navy units"},
+	{Dir: CommanderOrders, Entity: "commander order", Description: "orders which can be given to commanders"},
+	{Dir: CommanderRanks, Entity: "commander rank", Description: "general and admiral ranks"},
+	{Dir: Companies, Entity: "company type", Description: "companies, and the buildings they own"},
+	{Dir: CountryCreation, Entity: "country creation rule", Description: "rules for creating countries during play"},
+	{Dir: CountryDefinitions, Entity: "country definition", Description: "country tags, with their color, culture, and capital"},
+	{Dir: CountryFormation, Entity: "country formation", Description: "countries which can be formed"},
+	{Dir: CountryRanks, Entity: "country rank", Description: "great power, major power, and other ranks"},
+	{Dir: CountryTypes, Entity: "country type", Description: "recognized, unrecognized, decentralized, and other country types"},
+	{Dir: Cultures, Entity: "culture", Description: "cultures, with their traits and graphics"},
+	{Dir: Decisions, Entity: "decision", Description: "one off decisions a country can take"},
+	{Dir: Decrees, Entity: "decree", Description: "decrees which can be issued in states"},
+	{Dir: Defines, Entity: "define namespace", Description: "engine constants, grouped into namespaces"},
+	{Dir: DiplomaticActions, Entity: "diplomatic action", Description: "actions between countries"},
+	{Dir: DiplomaticPlays, Entity: "diplomatic play", Description: "diplomatic play types"},
+	{Dir: DiscriminationTraits, Entity: "discrimination trait", Description: "heritage, language and tradition traits of cultures"},
+	{Dir: DynamicCountryNames, Entity: "dynamic country name", Description: "country names which depend on government"},
+	{Dir: FlagDefinitions, Entity: "flag definition", Description: "country flags, and when they are used"},
+	{Dir: GameRules, Entity: "game rule", Description: "options selectable when starting a game"},
+	{Dir: Goods, Entity: "good", Description: "tradeable goods and their base prices"},
+	{Dir: GovernmentTypes, Entity: "government type", Description: "government types, and the laws they require"},
+	{Dir: History, Entity: "history entry", Description: "the starting state of the world, in nested directories"},
+	{Dir: Ideologies, Entity: "ideology", Description: "ideologies of interest groups and characters"},
+	{Dir: Institutions, Entity: "institution", Description: "institutions established by laws"},
+	{Dir: InterestGroupTraits, Entity: "interest group trait", Description: "traits granted by interest group approval"},
+	{Dir: InterestGroups, Entity: "interest group", Description: "political interest groups"},
+	{Dir: JournalEntries, Entity: "journal entry", Description: "journal entries, with their triggers and effects"},
+	{Dir: LawGroups, Entity: "law group", Description: "law groups, each of which has one enacted law"},
+	{Dir: Laws, Entity: "law", Description: "laws, and the law group each belongs to"},
+	{Dir: LegitimacyLevels, Entity: "legitimacy level", Description: "effects of government legitimacy"},
+	{Dir: MobilizationOptions, Entity: "mobilization option", Description: "options for mobilizing armies"},
+	{Dir: ModifierTypeDefinitions, Entity: "modifier type", Description: "how each modifier type is displayed"},
+	{Dir: Modifiers, Entity: "static modifier", Description: "named modifiers applied by the engine and scripts"},
+	{Dir: NamedColors, Entity: "named color", Description: "colors which can be referenced by name"},
+	{Dir: OnActions, Entity: "on action", Description: "effects run when game events happen"},
+	{Dir: PopNeeds, Entity: "pop need", Description: "needs of pops, and the goods which satisfy them"},
+	{Dir: PopTypes, Entity: "pop type", Description: "pop professions, with their strata and wages"},
+	{Dir: PowerBlocPrinciples, Entity: "power bloc principle", Description: "principles which can be adopted by power blocs"},
+	{Dir: PrestigeGoods, Entity: "prestige good", Description: "branded goods which give prestige"},
+	{Dir: ProductionMethodGroups, Entity: "production method group", Description: "mutually exclusive production methods of a building"},
+	{Dir: ProductionMethods, Entity: "production method", Description: "inputs, outputs, and employment of buildings"},
+	{Dir: ProposalTypes, Entity: "proposal type", Description: "proposals which can be made to a power bloc"},
+	{Dir: Religions, Entity: "religion", Description: "religions, with their traits and taboos"},
+	{Dir: ScriptValues, Entity: "script value", Description: "named values computed by script"},
+	{Dir: ScriptedEffects, Entity: "scripted effect", Description: "reusable effects"},
+	{Dir: ScriptedTriggers, Entity: "scripted trigger", Description: "reusable triggers"},
+	{Dir: StateTraits, Entity: "state trait", Description: "traits of states, such as resources and terrain"},
+	{Dir: StrategicRegions, Entity: "strategic region", Description: "groups of state regions used for diplomacy and AI"},
+	{Dir: SubjectTypes, Entity: "subject type", Description: "puppet, dominion, protectorate, and other subject types"},
+	{Dir: Technologies, Entity: "technology", Description: "technologies and their prerequisites"},
+	{Dir: TechnologyEras, Entity: "era", Description: "technology eras, and the cost of researching in each"},
+	{Dir: WarGoalTypes, Entity: "war goal type", Description: "war goals which can be added to diplomatic plays"},
+}
+
+var registryIndex = func() map[DataDir]int {
+	index := make(map[DataDir]int, len(registry))
+	for i, info := range registry {
+		index[info.Dir] = i
+	}
+	return index
+}()
+
+// All returns every known DataDir with its description, so tools can iterate over the whole catalogue
+func All() []Info {
+	infos := make([]Info, len(registry))
+	copy(infos, registry)
+	return infos
+}
+
+// Lookup returns the description of a known DataDir
+func Lookup(d DataDir) (Info, bool) {
+	i, ok := registryIndex[d]
+	if !ok {
+		return Info{}, false
+	}
+	return registry[i], true
+}
+
+// Known reports whether the DataDir is in the registry
+func (d DataDir) Known() bool {
+	_, ok := registryIndex[d]
+	return ok
+}
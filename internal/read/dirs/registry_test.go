@@ -0,0 +1,53 @@
+package dirs
+
+import "testing"
+
+func TestAll_isUniqueAndDescribed(t *testing.T) {
+	seen := map[DataDir]bool{}
+	for _, info := range All() {
+		if seen[info.Dir] {
+			t.Errorf("%s is registered more than once", info.Dir)
+		}
+		seen[info.Dir] = true
+		if info.Entity == "" || info.Description == "" {
+			t.Errorf("%s is missing an entity type or description", info.Dir)
+		}
+	}
+}
+
+func TestAll_coversCatalogue(t *testing.T) {
+	expected := []DataDir{
+		PopTypes, Laws, LawGroups, InterestGroups, Ideologies, Institutions, Decrees, Companies,
+		CombatUnitTypes, Modifiers, ScriptValues, ScriptedTriggers, ScriptedEffects, JournalEntries,
+		CharacterTraits, Religions, Cultures, CountryDefinitions, StateTraits, StrategicRegions, Defines,
+		PopNeeds, BuyPackages,
+		BuildingGroups, Buildings, Goods, ProductionMethodGroups, ProductionMethods, Technologies, TechnologyEras,
+	}
+	for _, d := range expected {
+		if !d.Known() {
+			t.Errorf("%s is not registered", d)
+		}
+	}
+}
+
+func TestLookup(t *testing.T) {
+	info, ok := Lookup(PopTypes)
+	if !ok {
+		t.Fatalf("%s is not registered", PopTypes)
+	}
+	if info.Entity != "pop type" {
+		t.Errorf("expected: %s, actual: %s", "pop type", info.Entity)
+	}
+
+	if _, ok := Lookup("DOES-NOT-EXIST"); ok {
+		t.Errorf("Lookup returned ok for an unknown dir")
+	}
+}
+
+func TestAll_returnsCopy(t *testing.T) {
+	infos := All()
+	infos[0].Description = "changed"
+	if All()[0].Description == "changed" {
+		t.Errorf("All should not expose the registry")
+	}
+}
@@ -0,0 +1,100 @@
+package dirs
+
+import (
+	"errors"
+	"io/fs"
+	"path"
+	"sort"
+	"vic3-data-reader/internal/read/files"
+)
+
+// Root is anything which can list the data files of a DataDir:
+// a single GameInstall, or a Stack of the game and its mods
+type Root interface {
+	Files(d DataDir, opts ...ListOption) ([]files.FSFile, error)
+	LocalizationFiles(language string) ([]files.FSFile, error)
+}
+
+// Layer is one root in a load order, i.e. the game or a single mod.
+// The FS is rooted at the directory containing common/, which is game/ for the base game.
+type Layer struct {
+	// Name is used as the origin of every file in the layer
+	Name string
+	FS   fs.FS
+	// ReplacePaths are slash separated directories, such as common/goods, whose files in earlier layers are hidden
+	ReplacePaths []string
+}
+
+// Layer returns the base game as the first layer of a Stack
+func (g GameInstall) Layer() Layer {
+	sub, err := fs.Sub(g.fsys, "game")
+	if err != nil {
+		// only possible for an invalid path, which "game" is not
+		panic(err)
+	}
+	return Layer{Name: "game", FS: sub}
+}
+
+// Stack is the game and its mods, in load order.
+//
+// Each DataDir is merged across layers the way the game does it:
+//   - a layer's replace paths hide the files of earlier layers in those directories
+//   - a file hides any file with the same path in an earlier layer
+//   - the remaining files are loaded in the usual order, so later files override definitions of the same key
+type Stack struct {
+	layers []Layer
+}
+
+func NewStack(layers ...Layer) *Stack {
+	return &Stack{layers: layers}
+}
+
+// Layers returns the layers in load order
+func (s *Stack) Layers() []Layer {
+	return s.layers
+}
+
+// Files returns the merged data files of the DataDir, each with the name of the layer which supplied it as its origin.
+// A layer which does not have the directory is skipped.
+func (s *Stack) Files(d DataDir, opts ...ListOption) ([]files.FSFile, error) {
+	return s.files(path.Join("common", string(d)), opts)
+}
+
+// files merges the files within the slash separated dir of each layer
+func (s *Stack) files(dir string, opts []ListOption) ([]files.FSFile, error) {
+	byName := map[string]files.FSFile{}
+
+	for _, layer := range s.layers {
+		for _, replaced := range layer.ReplacePaths {
+			for name := range byName {
+				if path.Dir(path.Join(dir, name)) == path.Clean(replaced) {
+					delete(byName, name)
+				}
+			}
+		}
+
+		names, err := dataFileNames(layer.FS, dir, opts)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			byName[name] = files.FSFile{FS: layer.FS, Path: path.Join(dir, name), Layer: layer.Name}
+		}
+	}
+
+	names := make([]string, 0, len(byName))
+	for name := range byName {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return loadsBefore(names[i], names[j])
+	})
+
+	srcs := make([]files.FSFile, len(names))
+	for i, name := range names {
+		srcs[i] = byName[name]
+	}
+	return srcs, nil
+}
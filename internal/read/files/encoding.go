@@ -0,0 +1,116 @@
+package files
+
+import (
+	"bufio"
+	"bytes"
+	"io"
+	"unicode/utf8"
+)
+
+// Encoding is the character encoding of a file
+type Encoding int
+
+const (
+	UTF8 Encoding = iota
+	// Windows1252 is a superset of Latin-1, used by older game files and many mods
+	Windows1252
+)
+
+func (e Encoding) String() string {
+	switch e {
+	case UTF8:
+		return "UTF-8"
+	case Windows1252:
+		return "Windows-1252"
+	default:
+		return "unknown"
+	}
+}
+
+// WithEncoding reads the file using the given encoding, rather than assuming UTF-8
+func WithEncoding(enc Encoding) Option {
+	return func(r *Reader) {
+		r.encoding = enc
+	}
+}
+
+// DetectEncoding reads the file as UTF-8 if it starts with a byte order mark or is valid UTF-8,
+// and as Windows-1252 otherwise. The whole file is read into memory to check it.
+func DetectEncoding() Option {
+	return func(r *Reader) {
+		r.detectEncoding = true
+	}
+}
+
+// Encoding returns the encoding the file is being read with
+func (r *Reader) Encoding() Encoding {
+	return r.encoding
+}
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+func detectEncoding(data []byte) Encoding {
+	if bytes.HasPrefix(data, utf8BOM) || utf8.Valid(data) {
+		return UTF8
+	}
+	return Windows1252
+}
+
+// decoded wraps src so that it provides UTF-8, detecting the encoding first if required
+func (r *Reader) decoded(src io.Reader) (io.Reader, error) {
+	if r.detectEncoding {
+		data, err := io.ReadAll(src)
+		if err != nil {
+			return nil, err
+		}
+		r.encoding = detectEncoding(data)
+		src = bytes.NewReader(data)
+	}
+
+	if r.encoding == Windows1252 {
+		return &windows1252Reader{src: bufio.NewReader(src)}, nil
+	}
+	return src, nil
+}
+
+// windows1252Reader transcodes Windows-1252 bytes to UTF-8
+type windows1252Reader struct {
+	src     io.ByteReader
+	pending []byte
+}
+
+func (w *windows1252Reader) Read(p []byte) (int, error) {
+	n := 0
+	for n < len(p) {
+		if len(w.pending) > 0 {
+			copied := copy(p[n:], w.pending)
+			w.pending = w.pending[copied:]
+			n += copied
+			continue
+		}
+
+		b, err := w.src.ReadByte()
+		if err != nil {
+			if n > 0 && err == io.EOF {
+				return n, nil
+			}
+			return n, err
+		}
+		w.pending = utf8.AppendRune(w.pending[:0], windows1252Rune(b))
+	}
+	return n, nil
+}
+
+// windows1252Runes are the characters in 0x80-0x9F, where Windows-1252 differs from Latin-1.
+// Bytes which are undefined in Windows-1252 are passed through as the matching control character.
+var windows1252Runes = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\u008D', 'Ž', '\u008F',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\u009D', 'ž', 'Ÿ',
+}
+
+func windows1252Rune(b byte) rune {
+	if b >= 0x80 && b <= 0x9F {
+		return windows1252Runes[b-0x80]
+	}
+	return rune(b)
+}
@@ -0,0 +1,116 @@
+package files
+
+import (
+	"io"
+	"testing"
+	"unicode/utf8"
+)
+
+const (
+	Windows1252Sample DataFile = "testdata/windows-1252.txt"
+	UTF8Sample        DataFile = "testdata/utf8.txt"
+)
+
+// readAll reads every rune from the reader, failing the test on any error other than EOF
+func readAll(t *testing.T, reader *Reader) string {
+	var contents []rune
+	for {
+		ch, err := reader.Next()
+		if err == io.EOF {
+			return string(contents)
+		} else if err != nil {
+			t.Fatalf("Next returned unexpected error: %v", err)
+		}
+		contents = append(contents, ch)
+	}
+}
+
+func TestNewReader_defaultEncodingIsUTF8(t *testing.T) {
+	reader, err := Windows1252Sample.NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	if reader.Encoding() != UTF8 {
+		t.Errorf("expected: %s, actual: %s", UTF8, reader.Encoding())
+	}
+
+	contents := readAll(t, reader)
+	if !containsRune(contents, utf8.RuneError) {
+		t.Errorf("expected invalid UTF-8 to be read as %q; actual: %q", utf8.RuneError, contents)
+	}
+}
+
+func TestDetectEncoding_windows1252(t *testing.T) {
+	reader, err := Windows1252Sample.NewReader(DetectEncoding())
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	if reader.Encoding() != Windows1252 {
+		t.Errorf("expected: %s, actual: %s", Windows1252, reader.Encoding())
+	}
+
+	expected := "name = \"Café €\"\n"
+	if contents := readAll(t, reader); contents != expected {
+		t.Errorf("expected: %q, actual: %q", expected, contents)
+	}
+}
+
+func TestDetectEncoding_utf8(t *testing.T) {
+	for _, df := range []DataFile{UTF8Sample, SmokeSample, Empty} {
+		reader, err := df.NewReader(DetectEncoding())
+		if err != nil {
+			t.Fatalf("could not open file: %v", err)
+		}
+		if reader.Encoding() != UTF8 {
+			t.Errorf("%s: expected: %s, actual: %s", df, UTF8, reader.Encoding())
+		}
+	}
+
+	reader, err := UTF8Sample.NewReader(DetectEncoding())
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	expected := "name = \"Café €\"\n"
+	if contents := readAll(t, reader); contents != expected {
+		t.Errorf("expected: %q, actual: %q", expected, contents)
+	}
+}
+
+func TestWithEncoding(t *testing.T) {
+	reader, err := Windows1252Sample.NewReader(WithEncoding(Windows1252))
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	if reader.Encoding() != Windows1252 {
+		t.Errorf("expected: %s, actual: %s", Windows1252, reader.Encoding())
+	}
+
+	expected := "name = \"Café €\"\n"
+	if contents := readAll(t, reader); contents != expected {
+		t.Errorf("expected: %q, actual: %q", expected, contents)
+	}
+}
+
+func TestWindows1252Rune(t *testing.T) {
+	tests := map[byte]rune{
+		'a':  'a',
+		0x80: '€',
+		0x9F: 'Ÿ',
+		0xE9: 'é',
+		0xFF: 'ÿ',
+	}
+	for b, expected := range tests {
+		if actual := windows1252Rune(b); actual != expected {
+			t.Errorf("%#x: expected: %q, actual: %q", b, expected, actual)
+		}
+	}
+}
+
+func containsRune(s string, r rune) bool {
+	for _, ch := range s {
+		if ch == r {
+			return true
+		}
+	}
+	return false
+}
@@ -3,39 +3,119 @@ package files
 import (
 	"bufio"
 	"fmt"
-	"log"
+	"io"
+	"io/fs"
 	"os"
 )
 
 // DataFile is a filepath with some convenience methods.
 type DataFile string
 
-// Reader loads a DataFile, and provides the runes in that file
+// Reader loads a file, and provides the runes in that file
 type Reader struct {
-	file   *os.File
+	name   string
+	closer io.Closer
 	reader *bufio.Reader
 	pos    Position
 	curr   *rune
 	err    error
+
+	skipBOM           bool
+	hasBOM            bool
+	normalizeNewlines bool
+	detectEncoding    bool
+	encoding          Encoding
+}
+
+// Option configures a Reader
+type Option func(*Reader)
+
+// SkipBOM skips a UTF-8 byte order mark at the start of the file, so it is not returned as a rune
+func SkipBOM() Option {
+	return func(r *Reader) {
+		r.skipBOM = true
+	}
 }
 
-func (df DataFile) NewReader() (*Reader, error) {
+// NormalizeNewlines returns "\r\n" and lone '\r' as a single '\n'
+func NormalizeNewlines() Option {
+	return func(r *Reader) {
+		r.normalizeNewlines = true
+	}
+}
+
+// EditorPositions applies every option needed for positions to match those shown by a text editor
+func EditorPositions() []Option {
+	return []Option{SkipBOM(), NormalizeNewlines()}
+}
+
+func (df DataFile) NewReader(opts ...Option) (*Reader, error) {
 	file, err := os.Open(string(df))
 	if err != nil {
 		return nil, err
 	}
+	return newReader(string(df), file, file, opts)
+}
 
-	rdr := bufio.NewReader(file)
+// NewReader reads from src, which need not be a file on disk.
+// The name is only used to describe the source, e.g. in error messages.
+// The caller keeps ownership of src, so Close does not close it.
+func NewReader(name string, src io.Reader, opts ...Option) (*Reader, error) {
+	return newReader(name, src, nil, opts)
+}
+
+// OpenFS opens the file at path in fsys, e.g. an embed.FS or a zip archive.
+// The reader is named after the path, and Close closes the file.
+func OpenFS(fsys fs.FS, path string, opts ...Option) (*Reader, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	return newReader(path, file, file, opts)
+}
+
+// newReader applies the options and wraps src; closer is closed by Close, and may be nil
+func newReader(name string, src io.Reader, closer io.Closer, opts []Option) (*Reader, error) {
 	pos := newPosition()
 
-	return &Reader{file: file, reader: rdr, pos: pos, curr: nil, err: nil}, nil
+	r := &Reader{name: name, closer: closer, pos: pos, curr: nil, err: nil}
+	for _, opt := range opts {
+		opt(r)
+	}
+	decoded, err := r.decoded(src)
+	if err != nil {
+		if closer != nil {
+			_ = closer.Close()
+		}
+		return nil, err
+	}
+	r.reader = bufio.NewReader(decoded)
+	if r.skipBOM {
+		r.discardBOM()
+	}
+	return r, nil
+}
+
+// discardBOM reads past a leading byte order mark, without advancing the position
+func (r *Reader) discardBOM() {
+	rn, _, err := r.reader.ReadRune()
+	if err == nil && rn != '\uFEFF' {
+		_ = r.reader.UnreadRune()
+	}
+	r.hasBOM = err == nil && rn == '\uFEFF'
+}
+
+// HasBOM reports whether SkipBOM found a byte order mark at the start of the file
+func (r *Reader) HasBOM() bool {
+	return r.hasBOM
 }
 
+// Close closes the underlying file, if the reader opened one
 func (r *Reader) Close() error {
-	if r.file == nil {
-		log.Fatalf("no file to close")
+	if r.closer == nil {
+		return nil
 	}
-	return r.file.Close()
+	return r.closer.Close()
 }
 
 func (r *Reader) Peek() (rune, error) {
@@ -46,21 +126,40 @@ func (r *Reader) Peek() (rune, error) {
 			return 0, fmt.Errorf("could not unread rune; reader is in an invalid state: %s", unReadErr)
 		}
 	}
+	if r.normalizeNewlines && rn == '\r' {
+		rn = '\n'
+	}
 	return rn, err
 }
 
 func (r *Reader) Next() (rune, error) {
 	r.pos.advance(r.curr, r.err)
-	curr, _, err := r.reader.ReadRune()
+	curr, err := r.readRune()
 	r.curr = &curr
 	r.err = err
 	return curr, r.err
 }
 
+// readRune reads the next rune, replacing "\r\n" with '\n' if newlines are normalized
+func (r *Reader) readRune() (rune, error) {
+	rn, _, err := r.reader.ReadRune()
+	if err == nil && r.normalizeNewlines && rn == '\r' {
+		next, peekErr := r.reader.Peek(1)
+		if peekErr == nil && next[0] == '\n' {
+			_, _ = r.reader.ReadByte()
+		}
+		rn = '\n'
+	}
+	return rn, err
+}
+
+// NextPosition returns the position of the rune Next would return, without advancing.
+// If there is no next rune, e.g. at the end of the file, the position is invalid.
 func (r *Reader) NextPosition() (Position, error) {
-	ch, err := r.Peek()
+	_, err := r.Peek()
 	pos := r.pos
-	pos.advance(&ch, err)
+	// line and col follow from the current rune, while the peek decides whether the next position is valid
+	pos.advance(r.curr, err)
 	return pos, err
 }
 
@@ -69,15 +168,15 @@ type Position struct {
 	line, col int
 }
 
-func (p *Position) Pos() int {
+func (p Position) Pos() int {
 	return p.pos
 }
 
-func (p *Position) Line() int {
+func (p Position) Line() int {
 	return p.line
 }
 
-func (p *Position) Col() int {
+func (p Position) Col() int {
 	return p.col
 }
 
@@ -92,10 +191,14 @@ func (p *Position) resetToInvalidState() {
 
 // advance params are the *current* rune/error *prior* to advancing
 // these will be nil on the first call to advance
+// lines and cols both start at 1, matching text editors
 func (p *Position) advance(r *rune, err error) {
 	if err == nil {
 		p.pos = p.pos + 1
-		if r != nil && *r == '\n' {
+		if r == nil {
+			p.line = 1
+			p.col = 1
+		} else if *r == '\n' {
 			p.line = p.line + 1
 			p.col = 1
 		} else {
@@ -108,6 +211,11 @@ func (p *Position) advance(r *rune, err error) {
 
 // getters
 
+// Name describes where the runes are read from, usually a file path
+func (r *Reader) Name() string {
+	return r.name
+}
+
 func (r *Reader) Err() error {
 	return r.err
 }
@@ -123,3 +231,8 @@ func (r *Reader) Line() int {
 func (r *Reader) Col() int {
 	return r.pos.col
 }
+
+// Position returns the position of the rune most recently returned by Next
+func (r *Reader) Position() Position {
+	return r.pos
+}
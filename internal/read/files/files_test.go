@@ -5,6 +5,7 @@ import (
 	"log"
 	"strings"
 	"testing"
+	"testing/fstest"
 )
 
 const (
@@ -13,6 +14,8 @@ const (
 	SingleA        DataFile = `testdata/single-a.txt`
 	NewlineSingleA DataFile = "testdata/newline-single-a.txt"
 	SmokeSample    DataFile = "testdata/00_goods.txt"
+	BOMSingleA     DataFile = "testdata/bom-single-a.txt"
+	CRLF           DataFile = "testdata/crlf.txt"
 )
 
 func TestNewReader_noErrOnExistingFile(t *testing.T) {
@@ -77,7 +80,7 @@ func TestClose(t *testing.T) {
 		t.Errorf("could not close reader: %v", err)
 	}
 
-	fileCloseErr := reader.file.Close()
+	fileCloseErr := reader.closer.Close()
 	if fileCloseErr != nil {
 		if !strings.HasSuffix(fileCloseErr.Error(), "file already closed") {
 			t.Errorf("reader.Close failed with unexpected error %v", fileCloseErr)
@@ -406,3 +409,244 @@ func TestSmoke(t *testing.T) {
 		t.Errorf("Next did not return EOF error: %v", err)
 	}
 }
+
+func TestNext_firstRuneIsLineOneColOne(t *testing.T) {
+	reader, err := SingleA.NewReader()
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	_, err = reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	}
+	if reader.Line() != 1 || reader.Col() != 1 {
+		t.Errorf("first rune should be at 1:1; actual: %d:%d", reader.Line(), reader.Col())
+	}
+}
+
+func TestNextPosition_afterNewline(t *testing.T) {
+	reader, err := NewlineSingleA.NewReader()
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	_, err = reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	}
+	nextPos, err := reader.NextPosition()
+	if err != nil {
+		t.Errorf("NextPosition returned unexpected error: %v", err)
+	}
+	_, err = reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	}
+
+	if reader.Line() != nextPos.Line() || reader.Col() != nextPos.Col() {
+		t.Errorf("NextPosition expected %d:%d, actual: %d:%d", reader.Line(), reader.Col(), nextPos.Line(), nextPos.Col())
+	}
+}
+
+func TestNextPosition_eof(t *testing.T) {
+	reader, err := SingleA.NewReader()
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	_, err = reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	}
+	nextPos, err := reader.NextPosition()
+	if err != io.EOF {
+		t.Errorf("NextPosition did not return EOF error: %v", err)
+	}
+	if nextPos.Pos() != -1 || nextPos.Line() != 0 || nextPos.Col() != 0 {
+		t.Errorf("NextPosition expected the invalid position at EOF, actual: pos %d %d:%d", nextPos.Pos(), nextPos.Line(), nextPos.Col())
+	}
+}
+
+func TestNext_bomIsReturnedByDefault(t *testing.T) {
+	reader, err := BOMSingleA.NewReader()
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	ch, err := reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	} else if ch != '\uFEFF' {
+		t.Errorf("first rune should be the BOM; actual: %q", ch)
+	}
+}
+
+func TestSkipBOM(t *testing.T) {
+	reader, err := BOMSingleA.NewReader(SkipBOM())
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	ch, err := reader.Peek()
+	if err != nil {
+		t.Errorf("Peek returned unexpected error: %v", err)
+	} else if ch != 'a' {
+		t.Errorf("peeked value should be 'a'; actual: %q", ch)
+	}
+
+	ch, err = reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	} else if ch != 'a' {
+		t.Errorf("Next value should be 'a'; actual: %q", ch)
+	}
+	if reader.Pos() != 0 || reader.Col() != 1 {
+		t.Errorf("BOM should not be counted; expected pos 0 col 1, actual: pos %d col %d", reader.Pos(), reader.Col())
+	}
+	if !reader.HasBOM() {
+		t.Errorf("HasBOM should be true for a file starting with a BOM")
+	}
+}
+
+func TestSkipBOM_noBOM(t *testing.T) {
+	reader, err := SingleA.NewReader(SkipBOM())
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	ch, err := reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	} else if ch != 'a' {
+		t.Errorf("Next value should be 'a'; actual: %q", ch)
+	}
+	if reader.HasBOM() {
+		t.Errorf("HasBOM should be false for a file without a BOM")
+	}
+}
+
+func TestNext_crlfIsReturnedByDefault(t *testing.T) {
+	reader, err := CRLF.NewReader()
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	_, _ = reader.Next()
+	ch, err := reader.Next()
+	if err != nil {
+		t.Errorf("Next returned unexpected error: %v", err)
+	} else if ch != '\r' {
+		t.Errorf("second rune should be '\\r'; actual: %q", ch)
+	}
+}
+
+func TestNormalizeNewlines(t *testing.T) {
+	reader, err := CRLF.NewReader(NormalizeNewlines())
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	var contents []rune
+	for {
+		ch, err := reader.Next()
+		if err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatalf("Next returned unexpected error: %v", err)
+		}
+		contents = append(contents, ch)
+		if ch == 'b' && (reader.Line() != 2 || reader.Col() != 1) {
+			t.Errorf("'b' should be at 2:1; actual: %d:%d", reader.Line(), reader.Col())
+		}
+	}
+
+	if string(contents) != "a\nb\n" {
+		t.Errorf("expected: %q, actual: %q", "a\nb\n", string(contents))
+	}
+}
+
+func TestNormalizeNewlines_peek(t *testing.T) {
+	reader, err := CRLF.NewReader(NormalizeNewlines())
+	if err != nil {
+		t.Errorf("could not open file")
+	}
+
+	_, _ = reader.Next()
+	ch, err := reader.Peek()
+	if err != nil {
+		t.Errorf("Peek returned unexpected error: %v", err)
+	} else if ch != '\n' {
+		t.Errorf("peeked value should be '\\n'; actual: %q", ch)
+	}
+}
+
+func TestNewReader_fromIOReader(t *testing.T) {
+	reader, err := NewReader("in-memory", strings.NewReader("\uFEFFa\r\nb"), SkipBOM(), NormalizeNewlines())
+	if err != nil {
+		t.Fatalf("NewReader returned unexpected error: %v", err)
+	}
+	if reader.Name() != "in-memory" {
+		t.Errorf("expected: %s, actual: %s", "in-memory", reader.Name())
+	}
+
+	expected := "a\nb"
+	if contents := readAll(t, reader); contents != expected {
+		t.Errorf("expected: %q, actual: %q", expected, contents)
+	}
+}
+
+func TestNewReader_closeWithoutFile(t *testing.T) {
+	reader, err := NewReader("in-memory", strings.NewReader("a"))
+	if err != nil {
+		t.Fatalf("NewReader returned unexpected error: %v", err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("Close returned unexpected error: %v", err)
+	}
+	if err := reader.Close(); err != nil {
+		t.Errorf("second call to Close returned unexpected error: %v", err)
+	}
+}
+
+func TestOpenFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common/goods/00_goods.txt": &fstest.MapFile{Data: []byte("a\nb")},
+	}
+	reader, err := OpenFS(fsys, "common/goods/00_goods.txt")
+	if err != nil {
+		t.Fatalf("OpenFS returned unexpected error: %v", err)
+	}
+	if reader.Name() != "common/goods/00_goods.txt" {
+		t.Errorf("expected: %s, actual: %s", "common/goods/00_goods.txt", reader.Name())
+	}
+
+	_, _ = reader.Next()
+	_, _ = reader.Next()
+	ch, _ := reader.Next()
+	if ch != 'b' || reader.Line() != 2 || reader.Col() != 1 {
+		t.Errorf("expected 'b' at 2:1, actual: %q at %d:%d", ch, reader.Line(), reader.Col())
+	}
+
+	if err := reader.Close(); err != nil {
+		t.Errorf("Close returned unexpected error: %v", err)
+	}
+}
+
+func TestOpenFS_errOnMissingFile(t *testing.T) {
+	_, err := OpenFS(fstest.MapFS{}, "DOES-NOT-EXIST.txt")
+	if err == nil {
+		t.Errorf("OpenFS did not return an error for missing file")
+	}
+}
+
+func TestNewReader_dataFileName(t *testing.T) {
+	reader, err := SingleA.NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+	if reader.Name() != string(SingleA) {
+		t.Errorf("expected: %s, actual: %s", SingleA, reader.Name())
+	}
+}
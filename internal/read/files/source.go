@@ -0,0 +1,51 @@
+package files
+
+import (
+	"io/fs"
+	"path"
+)
+
+// Source is anything which can be opened as a Reader, such as a DataFile on disk or an FSFile
+type Source interface {
+	// Name describes the source in positions and error messages
+	Name() string
+	// Origin is the game or mod the source belongs to, if known
+	Origin() string
+	NewReader(opts ...Option) (*Reader, error)
+}
+
+// Name is the file path
+func (df DataFile) Name() string {
+	return string(df)
+}
+
+// Origin is unknown for a plain file path
+func (df DataFile) Origin() string {
+	return ""
+}
+
+// FSFile is a file within an fs.FS, e.g. an os.DirFS of a game install, an embed.FS, or a zip archive
+type FSFile struct {
+	FS   fs.FS
+	Path string
+	// Layer is the name of the game or mod the file belongs to, and may be empty
+	Layer string
+}
+
+func (f FSFile) Origin() string {
+	return f.Layer
+}
+
+// Name is the slash separated path within the FS
+func (f FSFile) Name() string {
+	return f.Path
+}
+
+func (f FSFile) NewReader(opts ...Option) (*Reader, error) {
+	return OpenFS(f.FS, f.Path, opts...)
+}
+
+// Base is the last element of the path
+func (f FSFile) Base() string {
+	return path.Base(f.Path)
+}
@@ -0,0 +1,243 @@
+package lexer
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"vic3-data-reader/internal/read/files"
+)
+
+// Lexer splits the runes provided by a files.Reader into Clausewitz script tokens
+type Lexer struct {
+	rdr *files.Reader
+}
+
+func New(rdr *files.Reader) *Lexer {
+	return &Lexer{rdr: rdr}
+}
+
+// Next returns the next token in the file.
+// At the end of the file an EOF token is returned along with io.EOF.
+func (l *Lexer) Next() (Token, error) {
+	ch, err := l.skipWhitespace()
+	if err != nil {
+		return l.eof(err)
+	}
+	start := l.rdr.Position()
+
+	switch ch {
+	case '{':
+		return l.single(OpenBrace, ch, start), nil
+	case '}':
+		return l.single(CloseBrace, ch, start), nil
+	case '=':
+		return l.single(Assign, ch, start), nil
+	case '<':
+		return l.operator(LessThan, LessEqual, ch, start)
+	case '>':
+		return l.operator(GreaterThan, GreaterEqual, ch, start)
+	case '!':
+		return l.operator(Illegal, NotEqual, ch, start)
+	case '?':
+		return l.operator(Illegal, QuestionEqual, ch, start)
+	case '#':
+		return l.comment(ch, start)
+	case '"':
+		return l.quoted(start)
+	case '@':
+		if next, err := l.rdr.Peek(); err == nil && next == '[' {
+			return l.expression(start)
+		}
+		return l.word(ch, start)
+	default:
+		return l.word(ch, start)
+	}
+}
+
+// All reads tokens until the end of the file, returning every token except the final EOF
+func (l *Lexer) All() ([]Token, error) {
+	var toks []Token
+	for {
+		tok, err := l.Next()
+		if err == io.EOF {
+			return toks, nil
+		} else if err != nil {
+			return toks, err
+		}
+		toks = append(toks, tok)
+	}
+}
+
+// skipWhitespace advances past any whitespace, and returns the first rune that is not whitespace
+func (l *Lexer) skipWhitespace() (rune, error) {
+	for {
+		ch, err := l.rdr.Next()
+		if err != nil {
+			return ch, err
+		}
+		if !isWhitespace(ch) {
+			return ch, nil
+		}
+	}
+}
+
+func (l *Lexer) eof(err error) (Token, error) {
+	tok := Token{Type: EOF}
+	if err != io.EOF {
+		tok.Type = Illegal
+	}
+	return tok, err
+}
+
+func (l *Lexer) single(typ TokenType, ch rune, start files.Position) Token {
+	return Token{Type: typ, Text: string(ch), Start: start, End: start}
+}
+
+// operator handles operators which may optionally be followed by '='.
+// Pass Illegal as the plain type if the operator is only valid with a trailing '='.
+func (l *Lexer) operator(plain, withEq TokenType, ch rune, start files.Position) (Token, error) {
+	next, err := l.rdr.Peek()
+	if err == nil && next == '=' {
+		_, _ = l.rdr.Next()
+		return Token{Type: withEq, Text: string(ch) + "=", Start: start, End: l.rdr.Position()}, nil
+	} else if err != nil && err != io.EOF {
+		return Token{Type: Illegal, Text: string(ch), Start: start, End: start}, err
+	}
+
+	tok := l.single(plain, ch, start)
+	if plain == Illegal {
+		return tok, newError(start, "unexpected %q, expected %q", ch, string(ch)+"=")
+	}
+	return tok, nil
+}
+
+// comment reads from the '#' to the end of the line. The trailing newline is not part of the comment.
+func (l *Lexer) comment(ch rune, start files.Position) (Token, error) {
+	var sb strings.Builder
+	sb.WriteRune(ch)
+	end := start
+	for {
+		next, err := l.rdr.Peek()
+		if err == io.EOF || (err == nil && next == '\n') {
+			break
+		} else if err != nil {
+			return Token{Type: Illegal, Text: sb.String(), Start: start, End: end}, err
+		}
+		next, _ = l.rdr.Next()
+		sb.WriteRune(next)
+		end = l.rdr.Position()
+	}
+	text := strings.TrimRight(sb.String(), "\r")
+	return Token{Type: Comment, Text: text, Start: start, End: end}, nil
+}
+
+// quoted reads a double-quoted string. The token text does not include the surrounding quotes.
+func (l *Lexer) quoted(start files.Position) (Token, error) {
+	var sb strings.Builder
+	for {
+		ch, err := l.rdr.Next()
+		if err == io.EOF {
+			return Token{Type: Illegal, Text: sb.String(), Start: start, End: start}, newError(start, "unterminated string")
+		} else if err != nil {
+			return Token{Type: Illegal, Text: sb.String(), Start: start, End: start}, err
+		}
+
+		switch ch {
+		case '"':
+			return Token{Type: String, Text: sb.String(), Start: start, End: l.rdr.Position()}, nil
+		case '\\':
+			next, err := l.rdr.Peek()
+			if err == nil && (next == '"' || next == '\\') {
+				ch, _ = l.rdr.Next()
+			}
+		}
+		sb.WriteRune(ch)
+	}
+}
+
+// expression reads inline math such as `@[ cost * 2 ]`, which may contain whitespace.
+// The token text is the trimmed expression between the brackets.
+func (l *Lexer) expression(start files.Position) (Token, error) {
+	_, _ = l.rdr.Next() // '['
+	var sb strings.Builder
+	for {
+		ch, err := l.rdr.Next()
+		if err == io.EOF {
+			return Token{Type: Illegal, Text: sb.String(), Start: start, End: start}, newError(start, "unterminated expression")
+		} else if err != nil {
+			return Token{Type: Illegal, Text: sb.String(), Start: start, End: start}, err
+		}
+		if ch == ']' {
+			return Token{Type: Expression, Text: strings.TrimSpace(sb.String()), Start: start, End: l.rdr.Position()}, nil
+		}
+		sb.WriteRune(ch)
+	}
+}
+
+// word reads an unquoted run of runes, which is either a Number or an Identifier
+func (l *Lexer) word(ch rune, start files.Position) (Token, error) {
+	var sb strings.Builder
+	sb.WriteRune(ch)
+	end := start
+	for {
+		next, err := l.rdr.Peek()
+		if err == io.EOF || (err == nil && (isWhitespace(next) || isDelimiter(next))) {
+			break
+		} else if err != nil {
+			return Token{Type: Illegal, Text: sb.String(), Start: start, End: end}, err
+		}
+		next, _ = l.rdr.Next()
+		sb.WriteRune(next)
+		end = l.rdr.Position()
+	}
+
+	text := sb.String()
+	typ := Identifier
+	if isNumber(text) {
+		typ = Number
+	}
+	return Token{Type: typ, Text: text, Start: start, End: end}, nil
+}
+
+// isWhitespace includes the byte order mark, which some files start with
+func isWhitespace(ch rune) bool {
+	return unicode.IsSpace(ch) || ch == '\uFEFF'
+}
+
+func isDelimiter(ch rune) bool {
+	return strings.ContainsRune(`{}=<>!?#"`, ch)
+}
+
+// isNumber reports whether s is an optionally signed integer or decimal, e.g. 5, -0.25, .5
+func isNumber(s string) bool {
+	if len(s) > 0 && (s[0] == '-' || s[0] == '+') {
+		s = s[1:]
+	}
+	digits, dots := 0, 0
+	for _, ch := range s {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits++
+		case ch == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
+
+// Error is a lexing error at a position in the file
+type Error struct {
+	Pos files.Position
+	Msg string
+}
+
+func newError(pos files.Position, format string, args ...any) *Error {
+	return &Error{Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%d:%d: %s", e.Pos.Line(), e.Pos.Col(), e.Msg)
+}
@@ -0,0 +1,209 @@
+package lexer
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	Empty        files.DataFile = "testdata/empty.txt"
+	Goods        files.DataFile = "testdata/goods.txt"
+	Operators    files.DataFile = "testdata/operators.txt"
+	Scalars      files.DataFile = "testdata/scalars.txt"
+	Strings      files.DataFile = "testdata/strings.txt"
+	Unterminated files.DataFile = "testdata/unterminated.txt"
+	Expressions  files.DataFile = "testdata/expressions.txt"
+	Unclosed     files.DataFile = "testdata/unterminated-expression.txt"
+)
+
+// lexAll reads all tokens from the file, failing the test on any error
+func lexAll(t *testing.T, df files.DataFile) []Token {
+	reader, err := df.NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+	defer func() { _ = reader.Close() }()
+
+	toks, err := New(reader).All()
+	if err != nil {
+		t.Fatalf("All returned unexpected error: %v", err)
+	}
+	return toks
+}
+
+func checkTypes(t *testing.T, toks []Token, expected []TokenType) {
+	if len(toks) != len(expected) {
+		t.Fatalf("expected %d tokens, actual: %d (%v)", len(expected), len(toks), toks)
+	}
+	for i, tok := range toks {
+		if tok.Type != expected[i] {
+			t.Errorf("token %d: expected type %s, actual: %s", i, expected[i], tok.Type)
+		}
+	}
+}
+
+func TestNext_eofReturnsEOFErr(t *testing.T) {
+	reader, err := Empty.NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+
+	tok, err := New(reader).Next()
+	if err != io.EOF {
+		t.Errorf("Next did not return EOF error: %v", err)
+	} else if tok.Type != EOF {
+		t.Errorf("expected EOF token, actual: %s", tok)
+	}
+}
+
+func TestNext_goods(t *testing.T) {
+	toks := lexAll(t, Goods)
+	expected := []TokenType{
+		Identifier, Assign, OpenBrace,
+		Identifier, Assign, String,
+		Identifier, Assign, Number,
+		Identifier, Assign, Identifier,
+		Identifier, Assign, Number,
+		Identifier, Assign, Number, Comment,
+		CloseBrace,
+	}
+	checkTypes(t, toks, expected)
+}
+
+func TestNext_bomIsSkipped(t *testing.T) {
+	toks := lexAll(t, Goods)
+	if toks[0].Text != "ammunition" {
+		t.Errorf("expected: %s, actual: %s", "ammunition", toks[0].Text)
+	}
+}
+
+func TestNext_trailingComment(t *testing.T) {
+	toks := lexAll(t, Goods)
+	comment := toks[18]
+	if comment.Text != "# 250" {
+		t.Errorf("expected: %q, actual: %q", "# 250", comment.Text)
+	}
+	number := toks[17]
+	if number.Text != "5" {
+		t.Errorf("comment should not be part of the preceding value; expected: %q, actual: %q", "5", number.Text)
+	}
+}
+
+func TestNext_positions(t *testing.T) {
+	toks := lexAll(t, Goods)
+
+	// texture = "gfx/..." on the second line, indented by a tab
+	texture := toks[3]
+	if texture.Start.Line() != toks[0].Start.Line()+1 {
+		t.Errorf("expected texture to be one line after ammunition; actual lines %d and %d", toks[0].Start.Line(), texture.Start.Line())
+	}
+	if texture.Start.Col() != 2 {
+		t.Errorf("expected start col: %d, actual: %d", 2, texture.Start.Col())
+	}
+	if texture.End.Col() != 8 {
+		t.Errorf("expected end col: %d, actual: %d", 8, texture.End.Col())
+	}
+
+	str := toks[5]
+	expectedLen := len(str.Text) + 2
+	if actualLen := str.End.Pos() - str.Start.Pos() + 1; actualLen != expectedLen {
+		t.Errorf("quoted string should span its quotes; expected length %d, actual: %d", expectedLen, actualLen)
+	}
+}
+
+func TestNext_operators(t *testing.T) {
+	toks := lexAll(t, Operators)
+	expected := []TokenType{LessThan, GreaterThan, LessEqual, GreaterEqual, NotEqual, QuestionEqual, Assign}
+	for i, typ := range expected {
+		tok := toks[i*3+1]
+		if tok.Type != typ {
+			t.Errorf("expected: %s, actual: %s", typ, tok)
+		}
+		if !tok.Type.IsOperator() {
+			t.Errorf("%s should be an operator", tok.Type)
+		}
+	}
+}
+
+func TestNext_scalars(t *testing.T) {
+	toks := lexAll(t, Scalars)
+	expected := map[string]TokenType{
+		"1":           Number,
+		"-0.25":       Number,
+		"1836.1.1":    Identifier,
+		"scope:actor": Identifier,
+	}
+	for i := 2; i < len(toks); i += 3 {
+		typ, ok := expected[toks[i].Text]
+		if !ok {
+			t.Errorf("unexpected token: %s", toks[i])
+		} else if typ != toks[i].Type {
+			t.Errorf("%q: expected: %s, actual: %s", toks[i].Text, typ, toks[i].Type)
+		}
+	}
+}
+
+func TestNext_strings(t *testing.T) {
+	toks := lexAll(t, Strings)
+	if toks[2].Text != "Small Arms" {
+		t.Errorf("expected: %q, actual: %q", "Small Arms", toks[2].Text)
+	}
+	if toks[5].Text != `say "hi"` {
+		t.Errorf("expected: %q, actual: %q", `say "hi"`, toks[5].Text)
+	}
+}
+
+func TestNext_unterminatedStringReturnsError(t *testing.T) {
+	reader, err := Unterminated.NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+
+	_, err = New(reader).All()
+	var lexErr *Error
+	if !errors.As(err, &lexErr) {
+		t.Fatalf("expected a lexer error, actual: %v", err)
+	}
+	if lexErr.Pos.Col() != 8 {
+		t.Errorf("error should point at the opening quote; expected col %d, actual: %d", 8, lexErr.Pos.Col())
+	}
+}
+
+func TestNext_expressions(t *testing.T) {
+	toks := lexAll(t, Expressions)
+	checkTypes(t, toks, []TokenType{
+		Identifier, Assign, Identifier,
+		Identifier, Assign, Expression,
+		Identifier, Assign, Expression,
+	})
+	if toks[2].Text != "@cost" {
+		t.Errorf("expected: %q, actual: %q", "@cost", toks[2].Text)
+	}
+	if toks[5].Text != "cost * 2" {
+		t.Errorf("expected: %q, actual: %q", "cost * 2", toks[5].Text)
+	}
+	if toks[5].Start.Col() != 10 || toks[5].End.Col() != 22 {
+		t.Errorf("expected expression to span cols 10-22, actual: %d-%d", toks[5].Start.Col(), toks[5].End.Col())
+	}
+	if toks[8].Text != "cost/2" {
+		t.Errorf("expected: %q, actual: %q", "cost/2", toks[8].Text)
+	}
+}
+
+func TestNext_unterminatedExpressionReturnsError(t *testing.T) {
+	reader, err := Unclosed.NewReader()
+	if err != nil {
+		t.Fatalf("could not open file: %v", err)
+	}
+
+	_, err = New(reader).All()
+	var lexErr *Error
+	if !errors.As(err, &lexErr) {
+		t.Fatalf("expected a lexer error, actual: %v", err)
+	}
+	if lexErr.Pos.Col() != 5 {
+		t.Errorf("error should point at the '@'; expected col %d, actual: %d", 5, lexErr.Pos.Col())
+	}
+}
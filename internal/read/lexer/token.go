@@ -0,0 +1,80 @@
+package lexer
+
+import (
+	"fmt"
+	"vic3-data-reader/internal/read/files"
+)
+
+type TokenType int
+
+const (
+	Illegal TokenType = iota
+	EOF
+	Comment
+	Identifier
+	String // double-quoted; the token text excludes the quotes
+	Number
+	Expression    // @[ ... ] inline math; the token text excludes the @[ and ]
+	Assign        // =
+	OpenBrace     // {
+	CloseBrace    // }
+	LessThan      // <
+	GreaterThan   // >
+	LessEqual     // <=
+	GreaterEqual  // >=
+	NotEqual      // !=
+	QuestionEqual // ?=
+)
+
+var tokenTypeNames = map[TokenType]string{
+	Illegal:       "Illegal",
+	EOF:           "EOF",
+	Comment:       "Comment",
+	Identifier:    "Identifier",
+	String:        "String",
+	Number:        "Number",
+	Expression:    "Expression",
+	Assign:        "Assign",
+	OpenBrace:     "OpenBrace",
+	CloseBrace:    "CloseBrace",
+	LessThan:      "LessThan",
+	GreaterThan:   "GreaterThan",
+	LessEqual:     "LessEqual",
+	GreaterEqual:  "GreaterEqual",
+	NotEqual:      "NotEqual",
+	QuestionEqual: "QuestionEqual",
+}
+
+func (t TokenType) String() string {
+	name, ok := tokenTypeNames[t]
+	if !ok {
+		return fmt.Sprintf("TokenType(%d)", int(t))
+	}
+	return name
+}
+
+// IsOperator reports whether the token type separates a key from its value
+func (t TokenType) IsOperator() bool {
+	switch t {
+	case Assign, LessThan, GreaterThan, LessEqual, GreaterEqual, NotEqual, QuestionEqual:
+		return true
+	}
+	return false
+}
+
+// IsScalar reports whether the token type is a single value, i.e. not an operator, brace or comment
+func (t TokenType) IsScalar() bool {
+	return t == Identifier || t == String || t == Number || t == Expression
+}
+
+// Token is a single lexical element of a file.
+// Start and End are the positions of the first and last runes of the token.
+type Token struct {
+	Type       TokenType
+	Text       string
+	Start, End files.Position
+}
+
+func (t Token) String() string {
+	return fmt.Sprintf("%s(%q)@%d:%d", t.Type, t.Text, t.Start.Line(), t.Start.Col())
+}
@@ -0,0 +1,68 @@
+package mods
+
+import (
+	"encoding/json"
+	"fmt"
+	"vic3-data-reader/internal/read/decode"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/parser"
+)
+
+// Descriptor describes a mod, from either .metadata/metadata.json or descriptor.mod
+type Descriptor struct {
+	Name             string   `pdx:"name"`
+	ID               string   `pdx:"-"`
+	Version          string   `pdx:"version"`
+	SupportedVersion string   `pdx:"supported_version"`
+	ShortDescription string   `pdx:"-"`
+	Tags             []string `pdx:"tags"`
+	// RemoteFileID is the Steam Workshop id of the mod, if it was published there
+	RemoteFileID string `pdx:"remote_file_id"`
+	// Path is the mod directory, which is set in the descriptor files kept in the game's user mod directory
+	Path string `pdx:"path"`
+	// ReplacePaths are directories, such as common/goods, whose game and earlier mod files are ignored
+	ReplacePaths []string `pdx:"replace_path"`
+}
+
+// metadata is the layout of .metadata/metadata.json
+type metadata struct {
+	Name                 string   `json:"name"`
+	ID                   string   `json:"id"`
+	Version              string   `json:"version"`
+	SupportedGameVersion string   `json:"supported_game_version"`
+	ShortDescription     string   `json:"short_description"`
+	Tags                 []string `json:"tags"`
+	GameCustomData       struct {
+		ReplacePaths []string `json:"replace_paths"`
+	} `json:"game_custom_data"`
+}
+
+// ReadMetadata reads the contents of a .metadata/metadata.json file
+func ReadMetadata(data []byte) (Descriptor, error) {
+	var md metadata
+	if err := json.Unmarshal(data, &md); err != nil {
+		return Descriptor{}, err
+	}
+	return Descriptor{
+		Name:             md.Name,
+		ID:               md.ID,
+		Version:          md.Version,
+		SupportedVersion: md.SupportedGameVersion,
+		ShortDescription: md.ShortDescription,
+		Tags:             md.Tags,
+		ReplacePaths:     md.GameCustomData.ReplacePaths,
+	}, nil
+}
+
+// ReadDescriptor parses a descriptor.mod file, or one of the .mod files in the game's user mod directory
+func ReadDescriptor(src files.Source) (Descriptor, error) {
+	var desc Descriptor
+	file, err := parser.ParseSource(src)
+	if err != nil {
+		return desc, err
+	}
+	if err := decode.Unmarshal(file.Block, &desc); err != nil {
+		return desc, fmt.Errorf("%s:%w", src.Name(), err)
+	}
+	return desc, nil
+}
@@ -0,0 +1,109 @@
+package mods
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	// MetadataFile is the descriptor used by current versions of the game
+	MetadataFile = ".metadata/metadata.json"
+	// DescriptorFile is the older script format descriptor, which some mods still ship
+	DescriptorFile = "descriptor.mod"
+)
+
+// Mod is a mod directory, which has the same layout as the game directory, e.g. common/goods
+type Mod struct {
+	Descriptor
+	// Dir is the name of the mod's directory, which is the Workshop id for mods installed from Steam
+	Dir string
+	FS  fs.FS
+}
+
+// Open reads the mod's descriptor from .metadata/metadata.json, or descriptor.mod if there is no metadata file
+func Open(fsys fs.FS) (Mod, error) {
+	m := Mod{FS: fsys}
+
+	data, err := fs.ReadFile(fsys, MetadataFile)
+	if err == nil {
+		if m.Descriptor, err = ReadMetadata(data); err != nil {
+			return m, fmt.Errorf("%s: %w", MetadataFile, err)
+		}
+		return m, nil
+	} else if !errors.Is(err, fs.ErrNotExist) {
+		return m, err
+	}
+
+	m.Descriptor, err = ReadDescriptor(files.FSFile{FS: fsys, Path: DescriptorFile})
+	if errors.Is(err, fs.ErrNotExist) {
+		return m, fmt.Errorf("mod has neither %s nor %s", MetadataFile, DescriptorFile)
+	}
+	return m, err
+}
+
+// OpenDir opens the mod directory on disk
+func OpenDir(dir string) (Mod, error) {
+	m, err := Open(os.DirFS(dir))
+	m.Dir = filepath.Base(dir)
+	return m, err
+}
+
+// Discover opens every mod directly within dir of fsys, such as the Workshop content directory
+// or the game's user mod directory. Subdirectories which are not mods are skipped.
+func Discover(fsys fs.FS, dir string) ([]Mod, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	var mods []Mod
+	for _, entry := range entries {
+		if !entry.IsDir() {
+			continue
+		}
+		sub, err := fs.Sub(fsys, path.Join(dir, entry.Name()))
+		if err != nil {
+			return nil, err
+		}
+		if !isMod(sub) {
+			continue
+		}
+		m, err := Open(sub)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", entry.Name(), err)
+		}
+		m.Dir = entry.Name()
+		mods = append(mods, m)
+	}
+	return mods, nil
+}
+
+// isMod reports whether the directory has a descriptor
+func isMod(fsys fs.FS) bool {
+	for _, name := range []string{MetadataFile, DescriptorFile} {
+		if _, err := fs.Stat(fsys, name); err == nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Layer returns the mod as a layer of a dirs.Stack
+func (m Mod) Layer() dirs.Layer {
+	return dirs.Layer{Name: m.Name, FS: m.FS, ReplacePaths: m.ReplacePaths}
+}
+
+// NewStack stacks the mods on top of the game, in load order
+func NewStack(g dirs.GameInstall, mods ...Mod) *dirs.Stack {
+	layers := []dirs.Layer{g.Layer()}
+	for _, m := range mods {
+		layers = append(layers, m.Layer())
+	}
+	return dirs.NewStack(layers...)
+}
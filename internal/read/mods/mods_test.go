@@ -0,0 +1,258 @@
+package mods
+
+import (
+	"io/fs"
+	"os"
+	"path"
+	"path/filepath"
+	"slices"
+	"testing"
+	"testing/fstest"
+	"vic3-data-reader/internal/data/entity"
+	"vic3-data-reader/internal/read/dirs"
+	"vic3-data-reader/internal/read/files"
+)
+
+const (
+	TestdataDir        = "testdata"
+	GameDir            = "testdata/game"
+	BetterGoodsDir     = "testdata/mods/better_goods"
+	TotalConversionDir = "testdata/mods/total_conversion"
+	PlaysetFile        = "playset.json"
+	MissingPlayset     = "missing-playset.json"
+)
+
+type item struct {
+	Cost int `pdx:"cost"`
+}
+
+func openMod(t *testing.T, dir string) Mod {
+	m, err := OpenDir(dir)
+	if err != nil {
+		t.Fatalf("OpenDir returned unexpected error: %v", err)
+	}
+	return m
+}
+
+func stack(t *testing.T) *dirs.Stack {
+	return NewStack(dirs.OpenGameInstall(GameDir), openMod(t, BetterGoodsDir), openMod(t, TotalConversionDir))
+}
+
+// workshop lays the test mods out as the Steam workshop does, in directories named by their workshop id.
+// Only Total Conversion's descriptor has a remote_file_id, so Better Goods is matched by its directory name.
+func workshop(t *testing.T) fstest.MapFS {
+	fsys := fstest.MapFS{"workshop/not_a_mod/README.md": &fstest.MapFile{}}
+	for dir, id := range map[string]string{BetterGoodsDir: "1000001", TotalConversionDir: "1000002"} {
+		err := fs.WalkDir(os.DirFS(dir), ".", func(p string, entry fs.DirEntry, err error) error {
+			if err != nil || entry.IsDir() {
+				return err
+			}
+			data, err := os.ReadFile(filepath.Join(dir, p))
+			if p == "descriptor.mod" {
+				data = append(data, "remote_file_id=\""+id+"\"\n"...)
+			}
+			fsys[path.Join("workshop", id, p)] = &fstest.MapFile{Data: data}
+			return err
+		})
+		if err != nil {
+			t.Fatalf("could not copy %s: %v", dir, err)
+		}
+	}
+	return fsys
+}
+
+func TestOpen_metadata(t *testing.T) {
+	m := openMod(t, BetterGoodsDir)
+	if m.Name != "Better Goods" {
+		t.Errorf("expected: %s, actual: %s", "Better Goods", m.Name)
+	}
+	if len(m.ReplacePaths) != 0 {
+		t.Errorf("expected no replace paths, actual: %v", m.ReplacePaths)
+	}
+}
+
+func TestOpen_descriptor(t *testing.T) {
+	m := openMod(t, TotalConversionDir)
+	if m.Name != "Total Conversion" {
+		t.Errorf("expected: %s, actual: %s", "Total Conversion", m.Name)
+	}
+	if !slices.Equal(m.ReplacePaths, []string{"common/buildings"}) {
+		t.Errorf("expected: %v, actual: %v", []string{"common/buildings"}, m.ReplacePaths)
+	}
+}
+
+func TestOpen_errOnMissingDescriptor(t *testing.T) {
+	if _, err := OpenDir(GameDir); err == nil {
+		t.Errorf("OpenDir did not return an error for a dir without a descriptor")
+	}
+}
+
+func TestStack_Files_sameNameReplaces(t *testing.T) {
+	srcs, err := stack(t).Files(dirs.Goods)
+	if err != nil {
+		t.Fatalf("Files returned unexpected error: %v", err)
+	}
+
+	expected := []struct{ path, layer string }{
+		{"common/goods/00_goods.txt", "Better Goods"},
+		{"common/goods/01_tools.txt", "game"},
+		{"common/goods/02_more_goods.txt", "Better Goods"},
+		{"common/goods/03_tc_goods.txt", "Total Conversion"},
+	}
+	if len(srcs) != len(expected) {
+		t.Fatalf("expected %d files, actual: %v", len(expected), srcs)
+	}
+	for i, src := range srcs {
+		if src.Path != expected[i].path || src.Origin() != expected[i].layer {
+			t.Errorf("expected: %s from %s, actual: %s from %s", expected[i].path, expected[i].layer, src.Path, src.Origin())
+		}
+	}
+}
+
+func TestStack_Files_replacePath(t *testing.T) {
+	srcs, err := stack(t).Files(dirs.Buildings)
+	if err != nil {
+		t.Fatalf("Files returned unexpected error: %v", err)
+	}
+	if len(srcs) != 1 || srcs[0].Base() != "00_tc_buildings.txt" {
+		t.Errorf("expected only the total conversion buildings, actual: %v", srcs)
+	}
+}
+
+func TestStack_provenance(t *testing.T) {
+	goods, err := entity.LoadInstall[item](stack(t), dirs.Goods)
+	if err != nil {
+		t.Fatalf("LoadInstall returned unexpected error: %v", err)
+	}
+
+	// small_arms is only in the game's 00_goods.txt, which the mod's file of the same name replaces
+	if goods.Len() != 3 {
+		t.Errorf("expected %d goods, actual: %v", 3, goods.Keys())
+	}
+	expected := map[string]struct {
+		cost   int
+		origin string
+	}{
+		"ammunition": {55, "Better Goods"},
+		"tools":      {45, "Better Goods"},
+		"steel":      {70, "Total Conversion"},
+	}
+	for key, e := range expected {
+		entry, ok := goods.Entry(key)
+		if !ok {
+			t.Errorf("missing %s", key)
+			continue
+		}
+		if entry.Value.Cost != e.cost || entry.Source.Origin != e.origin {
+			t.Errorf("%s: expected cost %d from %s, actual: %d from %s", key, e.cost, e.origin, entry.Value.Cost, entry.Source)
+		}
+	}
+
+	steel, _ := goods.Entry("steel")
+	if steel.Source.String() != "common/goods/03_tc_goods.txt:1:1 (Total Conversion)" {
+		t.Errorf("unexpected source: %s", steel.Source)
+	}
+}
+
+func TestOpen_fullDescriptor(t *testing.T) {
+	m := openMod(t, BetterGoodsDir)
+	if m.ID != "better.goods" || m.Version != "1.2" || m.SupportedVersion != "1.5.*" {
+		t.Errorf("unexpected metadata: %+v", m.Descriptor)
+	}
+	if !slices.Equal(m.Tags, []string{"Economy", "Balance"}) {
+		t.Errorf("expected: %v, actual: %v", []string{"Economy", "Balance"}, m.Tags)
+	}
+
+	tc := openMod(t, TotalConversionDir)
+	if tc.Version != "0.1" || tc.SupportedVersion != "1.5.*" || !slices.Equal(tc.Tags, []string{"Total Conversion"}) {
+		t.Errorf("unexpected descriptor: %+v", tc.Descriptor)
+	}
+	if tc.Dir != "total_conversion" {
+		t.Errorf("expected: %s, actual: %s", "total_conversion", tc.Dir)
+	}
+}
+
+func TestDiscover(t *testing.T) {
+	mods, err := Discover(workshop(t), "workshop")
+	if err != nil {
+		t.Fatalf("Discover returned unexpected error: %v", err)
+	}
+	if len(mods) != 2 {
+		t.Fatalf("expected %d mods, actual: %d", 2, len(mods))
+	}
+	if mods[0].Dir != "1000001" || mods[0].Name != "Better Goods" {
+		t.Errorf("unexpected mod: %+v", mods[0])
+	}
+	if mods[1].RemoteFileID != "1000002" {
+		t.Errorf("expected: %s, actual: %s", "1000002", mods[1].RemoteFileID)
+	}
+}
+
+func TestOpenPlayset(t *testing.T) {
+	p, err := OpenPlayset(os.DirFS(TestdataDir), PlaysetFile)
+	if err != nil {
+		t.Fatalf("OpenPlayset returned unexpected error: %v", err)
+	}
+	if p.Name != "Economy Overhaul" || len(p.Mods) != 3 {
+		t.Errorf("unexpected playset: %+v", p)
+	}
+
+	enabled := p.Enabled()
+	if len(enabled) != 2 || enabled[0].DisplayName != "Better Goods" || enabled[1].DisplayName != "Total Conversion" {
+		t.Errorf("expected enabled mods in position order, actual: %+v", enabled)
+	}
+}
+
+func TestPlayset_Stack(t *testing.T) {
+	p, err := OpenPlayset(os.DirFS(TestdataDir), PlaysetFile)
+	if err != nil {
+		t.Fatalf("OpenPlayset returned unexpected error: %v", err)
+	}
+	installed, err := Discover(workshop(t), "workshop")
+	if err != nil {
+		t.Fatalf("Discover returned unexpected error: %v", err)
+	}
+
+	s, err := p.Stack(dirs.OpenGameInstall(GameDir), installed)
+	if err != nil {
+		t.Fatalf("Stack returned unexpected error: %v", err)
+	}
+	var names []string
+	for _, layer := range s.Layers() {
+		names = append(names, layer.Name)
+	}
+	if !slices.Equal(names, []string{"game", "Better Goods", "Total Conversion"}) {
+		t.Errorf("unexpected load order: %v", names)
+	}
+
+	buildings, err := s.Files(dirs.Buildings)
+	if err != nil {
+		t.Fatalf("Files returned unexpected error: %v", err)
+	}
+	if len(buildings) != 1 || buildings[0].Origin() != "Total Conversion" {
+		t.Errorf("expected only the total conversion buildings, actual: %v", buildings)
+	}
+}
+
+func TestPlayset_Resolve_missingMod(t *testing.T) {
+	p, err := OpenPlayset(os.DirFS(TestdataDir), MissingPlayset)
+	if err != nil {
+		t.Fatalf("OpenPlayset returned unexpected error: %v", err)
+	}
+	installed, _ := Discover(workshop(t), "workshop")
+	if _, err := p.Resolve(installed); err == nil {
+		t.Errorf("Resolve did not return an error for a mod which is not installed")
+	}
+}
+
+func TestReadDescriptor_userModFile(t *testing.T) {
+	desc, err := ReadDescriptor(files.FSFile{FS: fstest.MapFS{
+		"mod/ugc_1000001.mod": &fstest.MapFile{Data: []byte("name=\"Better Goods\"\npath=\"/home/user/workshop/1000001\"\nremote_file_id=\"1000001\"\n")},
+	}, Path: "mod/ugc_1000001.mod"})
+	if err != nil {
+		t.Fatalf("ReadDescriptor returned unexpected error: %v", err)
+	}
+	if desc.Path != "/home/user/workshop/1000001" || desc.RemoteFileID != "1000001" {
+		t.Errorf("unexpected descriptor: %+v", desc)
+	}
+}
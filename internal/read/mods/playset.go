@@ -0,0 +1,103 @@
+package mods
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/fs"
+	"sort"
+	"vic3-data-reader/internal/read/dirs"
+)
+
+// Playset is a playset exported from the Paradox launcher
+type Playset struct {
+	Game string       `json:"game"`
+	Name string       `json:"name"`
+	Mods []PlaysetMod `json:"mods"`
+}
+
+// PlaysetMod is a single mod of a playset. Mods are identified by whichever ids the launcher knows.
+type PlaysetMod struct {
+	DisplayName string `json:"displayName"`
+	Enabled     bool   `json:"enabled"`
+	Position    int    `json:"position"`
+	SteamID     string `json:"steamId"`
+	PdxID       string `json:"pdxId"`
+}
+
+// ReadPlayset reads a playset in the launcher's export format
+func ReadPlayset(r io.Reader) (Playset, error) {
+	var p Playset
+	err := json.NewDecoder(r).Decode(&p)
+	return p, err
+}
+
+// OpenPlayset reads the exported playset at path in fsys
+func OpenPlayset(fsys fs.FS, path string) (Playset, error) {
+	file, err := fsys.Open(path)
+	if err != nil {
+		return Playset{}, err
+	}
+	defer func() { _ = file.Close() }()
+
+	p, err := ReadPlayset(file)
+	if err != nil {
+		return p, fmt.Errorf("%s: %w", path, err)
+	}
+	return p, nil
+}
+
+// Enabled returns the enabled mods in load order
+func (p Playset) Enabled() []PlaysetMod {
+	var enabled []PlaysetMod
+	for _, m := range p.Mods {
+		if m.Enabled {
+			enabled = append(enabled, m)
+		}
+	}
+	sort.SliceStable(enabled, func(i, j int) bool {
+		return enabled[i].Position < enabled[j].Position
+	})
+	return enabled
+}
+
+// matches reports whether the installed mod is the playset's mod.
+// Steam ids are matched against the Workshop id and directory name, and otherwise mods are matched by name.
+func (pm PlaysetMod) matches(m Mod) bool {
+	if pm.SteamID != "" {
+		return pm.SteamID == m.RemoteFileID || pm.SteamID == m.Dir
+	}
+	if pm.PdxID != "" && pm.PdxID == m.ID {
+		return true
+	}
+	return pm.DisplayName != "" && pm.DisplayName == m.Name
+}
+
+// Resolve finds each enabled mod of the playset among the installed mods, in load order.
+// It is an error for an enabled mod not to be installed.
+func (p Playset) Resolve(installed []Mod) ([]Mod, error) {
+	var mods []Mod
+	for _, pm := range p.Enabled() {
+		found := false
+		for _, m := range installed {
+			if pm.matches(m) {
+				mods = append(mods, m)
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, fmt.Errorf("playset %q: mod %q is not installed", p.Name, pm.DisplayName)
+		}
+	}
+	return mods, nil
+}
+
+// Stack resolves the playset's mods, and stacks them on top of the game in load order
+func (p Playset) Stack(g dirs.GameInstall, installed []Mod) (*dirs.Stack, error) {
+	mods, err := p.Resolve(installed)
+	if err != nil {
+		return nil, err
+	}
+	return NewStack(g, mods...), nil
+}
@@ -0,0 +1,7 @@
+version="0.1"
+tags={
+	"Total Conversion"
+}
+name="Total Conversion"
+replace_path="common/buildings"
+supported_version="1.5.*"
@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/lexer"
+)
+
+// Node is any element of the syntax tree
+type Node interface {
+	Start() files.Position
+	End() files.Position
+}
+
+// Value is anything which can be on the right hand side of a Field, i.e. a *Scalar or a *Block
+type Value interface {
+	Node
+	value()
+}
+
+// File is the syntax tree of a whole file.
+// The top level of a file is a Block without braces.
+type File struct {
+	Name     string
+	Encoding files.Encoding
+	// Constants are the file's `@name = value` definitions, in order.
+	// They are not included in the block's items, and references to them have already been substituted.
+	Constants []*Field
+	*Block
+}
+
+// Constant returns the value of the `@name` definition; name may be given with or without its '@'
+func (f *File) Constant(name string) (*Scalar, bool) {
+	name = constantName(name)
+	for i := len(f.Constants) - 1; i >= 0; i-- {
+		if f.Constants[i].Name() == name {
+			return f.Constants[i].Value.(*Scalar), true
+		}
+	}
+	return nil, false
+}
+
+// Scalar is a single identifier, number, quoted string, or inline math expression
+type Scalar struct {
+	Token lexer.Token
+	// Original is the `@name` reference or `@[ ... ]` expression the value was substituted for,
+	// and is nil for values written directly
+	Original *lexer.Token
+}
+
+func (s *Scalar) value() {}
+
+func (s *Scalar) Start() files.Position {
+	return s.Token.Start
+}
+
+func (s *Scalar) End() files.Position {
+	return s.Token.End
+}
+
+// Text is the scalar's text; quotes are not included for quoted strings
+func (s *Scalar) Text() string {
+	return s.Token.Text
+}
+
+func (s *Scalar) Quoted() bool {
+	return s.Token.Type == lexer.String
+}
+
+func (s *Scalar) IsNumber() bool {
+	return s.Token.Type == lexer.Number
+}
+
+// Field is a key, operator, and value, e.g. `cost = 50` or `age >= 30`
+type Field struct {
+	Key   *Scalar
+	Op    lexer.Token
+	Value Value
+}
+
+func (f *Field) Start() files.Position {
+	return f.Key.Start()
+}
+
+func (f *Field) End() files.Position {
+	return f.Value.End()
+}
+
+// Name is the text of the field's key
+func (f *Field) Name() string {
+	return f.Key.Text()
+}
+
+// IsAssignment reports whether the field uses '=' rather than a comparison operator
+func (f *Field) IsAssignment() bool {
+	return f.Op.Type == lexer.Assign
+}
+
+// Block is a braced sequence of items.
+// Each item is a *Field, *Scalar, or *Block, and items are kept in file order.
+//
+// Blocks are used for objects (`{ cost = 50 }`), arrays (`{ a b c }`),
+// anonymous block lists (`{ { a } { b } }`), and any mix of these.
+type Block struct {
+	// Tag is set for tagged blocks such as `rgb { 255 0 0 }`
+	Tag         string
+	Open, Close files.Position
+	Items       []Node
+}
+
+func (b *Block) value() {}
+
+func (b *Block) Start() files.Position {
+	return b.Open
+}
+
+func (b *Block) End() files.Position {
+	return b.Close
+}
+
+func (b *Block) IsEmpty() bool {
+	return len(b.Items) == 0
+}
+
+// IsArray reports whether the block is non-empty and contains only scalars
+func (b *Block) IsArray() bool {
+	if b.IsEmpty() {
+		return false
+	}
+	for _, item := range b.Items {
+		if _, ok := item.(*Scalar); !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// Fields returns all fields in the block, in order
+func (b *Block) Fields() []*Field {
+	var fields []*Field
+	for _, item := range b.Items {
+		if f, ok := item.(*Field); ok {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Values returns all items in the block which are not fields, in order
+func (b *Block) Values() []Value {
+	var values []Value
+	for _, item := range b.Items {
+		if v, ok := item.(Value); ok {
+			values = append(values, v)
+		}
+	}
+	return values
+}
+
+// Scalars returns all scalar items in the block, in order
+func (b *Block) Scalars() []*Scalar {
+	var scalars []*Scalar
+	for _, item := range b.Items {
+		if s, ok := item.(*Scalar); ok {
+			scalars = append(scalars, s)
+		}
+	}
+	return scalars
+}
+
+// Blocks returns all anonymous block items, in order
+func (b *Block) Blocks() []*Block {
+	var blocks []*Block
+	for _, item := range b.Items {
+		if blk, ok := item.(*Block); ok {
+			blocks = append(blocks, blk)
+		}
+	}
+	return blocks
+}
+
+// GetAll returns every field with the given key, in order.
+// Paradox files often repeat keys, so use this rather than Get when that is expected.
+func (b *Block) GetAll(key string) []*Field {
+	var fields []*Field
+	for _, f := range b.Fields() {
+		if f.Name() == key {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Get returns the last field with the given key
+func (b *Block) Get(key string) (*Field, bool) {
+	fields := b.GetAll(key)
+	if len(fields) == 0 {
+		return nil, false
+	}
+	return fields[len(fields)-1], true
+}
+
+// Keys returns the distinct field keys of the block, in order of first appearance
+func (b *Block) Keys() []string {
+	var keys []string
+	seen := map[string]bool{}
+	for _, f := range b.Fields() {
+		if !seen[f.Name()] {
+			seen[f.Name()] = true
+			keys = append(keys, f.Name())
+		}
+	}
+	return keys
+}
@@ -0,0 +1,128 @@
+package parser
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+	"vic3-data-reader/internal/read/lexer"
+)
+
+// constantName adds the '@' prefix used by constant definitions, if it is missing
+func constantName(name string) string {
+	if strings.HasPrefix(name, "@") {
+		return name
+	}
+	return "@" + name
+}
+
+// isConstantRef reports whether the token refers to a constant, e.g. `@cost`
+func isConstantRef(tok lexer.Token) bool {
+	return tok.Type == lexer.Identifier && len(tok.Text) > 1 && strings.HasPrefix(tok.Text, "@")
+}
+
+// resolveConstants moves the top level `@name = value` definitions out of root, then replaces
+// every `@name` reference and `@[ ... ]` expression in the remaining values.
+// As in the game, a constant may only be used after its definition, both by other definitions and by ordinary items.
+func (p *Parser) resolveConstants(root *Block) ([]*Field, error) {
+	var defs []*Field
+	values := map[string]lexer.Token{}
+	var items []Node
+	for _, item := range root.Items {
+		f, ok := item.(*Field)
+		if !ok || !strings.HasPrefix(f.Name(), "@") {
+			resolved, err := p.resolveNode(item, values)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, resolved)
+			continue
+		}
+
+		s, ok := f.Value.(*Scalar)
+		if !ok || !f.IsAssignment() {
+			return nil, p.errorf(f.Start(), "constant %s must be assigned a single value", f.Name())
+		}
+		resolved, err := p.resolveScalar(s, values)
+		if err != nil {
+			return nil, err
+		}
+		f.Value = resolved
+		values[f.Name()] = resolved.Token
+		defs = append(defs, f)
+	}
+	root.Items = items
+	return defs, nil
+}
+
+func (p *Parser) resolveBlock(blk *Block, values map[string]lexer.Token) error {
+	for i, item := range blk.Items {
+		resolved, err := p.resolveNode(item, values)
+		if err != nil {
+			return err
+		}
+		blk.Items[i] = resolved
+	}
+	return nil
+}
+
+// resolveNode returns the node with its references and expressions replaced
+func (p *Parser) resolveNode(item Node, values map[string]lexer.Token) (Node, error) {
+	switch node := item.(type) {
+	case *Scalar:
+		return p.resolveScalar(node, values)
+	case *Block:
+		return node, p.resolveBlock(node, values)
+	case *Field:
+		switch val := node.Value.(type) {
+		case *Scalar:
+			resolved, err := p.resolveScalar(val, values)
+			if err != nil {
+				return nil, err
+			}
+			node.Value = resolved
+		case *Block:
+			if err := p.resolveBlock(val, values); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return item, nil
+}
+
+// resolveScalar returns the scalar which s stands for.
+// The result is positioned at s, so errors in decoding it point at the reference.
+func (p *Parser) resolveScalar(s *Scalar, values map[string]lexer.Token) (*Scalar, error) {
+	tok := s.Token
+	switch {
+	case isConstantRef(tok):
+		val, ok := values[tok.Text]
+		if !ok {
+			return nil, p.errorf(tok.Start, "undefined constant %s", tok.Text)
+		}
+		return &Scalar{Token: lexer.Token{Type: val.Type, Text: val.Text, Start: tok.Start, End: tok.End}, Original: &tok}, nil
+	case tok.Type == lexer.Expression:
+		lookup := func(name string) (float64, error) {
+			val, ok := values[constantName(name)]
+			if !ok {
+				return 0, p.errorf(tok.Start, "undefined constant %s in expression", constantName(name))
+			}
+			n, err := strconv.ParseFloat(val.Text, 64)
+			if err != nil || val.Type != lexer.Number {
+				return 0, p.errorf(tok.Start, "constant %s is not a number", constantName(name))
+			}
+			return n, nil
+		}
+		n, err := evalExpression(tok.Text, lookup)
+		if err != nil {
+			var parseErr *Error
+			if errors.As(err, &parseErr) {
+				return nil, err
+			}
+			return nil, p.errorf(tok.Start, "%s", err)
+		}
+		text := strconv.FormatFloat(n, 'f', -1, 64)
+		return &Scalar{Token: lexer.Token{Type: lexer.Number, Text: text, Start: tok.Start, End: tok.End}, Original: &tok}, nil
+	default:
+		return s, nil
+	}
+}
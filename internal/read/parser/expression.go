@@ -0,0 +1,165 @@
+package parser
+
+import (
+	"fmt"
+	"strconv"
+	"unicode"
+)
+
+// evalExpression evaluates the inside of an `@[ ... ]` expression.
+// Operands are numbers and constant names, which may be written with or without their '@'.
+// The operators are + - * / and parentheses, with the usual precedence.
+func evalExpression(expr string, lookup func(name string) (float64, error)) (float64, error) {
+	toks, err := splitExpression(expr)
+	if err != nil {
+		return 0, err
+	}
+	e := &exprParser{toks: toks, lookup: lookup}
+	v, err := e.sum()
+	if err != nil {
+		return 0, err
+	}
+	if e.i < len(e.toks) {
+		return 0, fmt.Errorf("unexpected %q in expression", e.toks[e.i])
+	}
+	return v, nil
+}
+
+// splitExpression splits an expression into numbers, names, operators and parentheses
+func splitExpression(expr string) ([]string, error) {
+	var toks []string
+	runes := []rune(expr)
+	for i := 0; i < len(runes); {
+		ch := runes[i]
+		switch {
+		case unicode.IsSpace(ch):
+			i++
+		case ch == '+' || ch == '-' || ch == '*' || ch == '/' || ch == '(' || ch == ')':
+			toks = append(toks, string(ch))
+			i++
+		case ch == '.' || unicode.IsDigit(ch) || ch == '@' || ch == '_' || unicode.IsLetter(ch):
+			j := i + 1
+			for j < len(runes) && (runes[j] == '.' || runes[j] == '_' || unicode.IsDigit(runes[j]) || unicode.IsLetter(runes[j])) {
+				j++
+			}
+			toks = append(toks, string(runes[i:j]))
+			i = j
+		default:
+			return nil, fmt.Errorf("unexpected %q in expression", ch)
+		}
+	}
+	return toks, nil
+}
+
+type exprParser struct {
+	toks   []string
+	i      int
+	lookup func(name string) (float64, error)
+}
+
+func (e *exprParser) peek() string {
+	if e.i < len(e.toks) {
+		return e.toks[e.i]
+	}
+	return ""
+}
+
+// sum is a sequence of products separated by + or -
+func (e *exprParser) sum() (float64, error) {
+	v, err := e.product()
+	if err != nil {
+		return 0, err
+	}
+	for op := e.peek(); op == "+" || op == "-"; op = e.peek() {
+		e.i++
+		rhs, err := e.product()
+		if err != nil {
+			return 0, err
+		}
+		if op == "+" {
+			v += rhs
+		} else {
+			v -= rhs
+		}
+	}
+	return v, nil
+}
+
+// product is a sequence of unary operands separated by * or /
+func (e *exprParser) product() (float64, error) {
+	v, err := e.unary()
+	if err != nil {
+		return 0, err
+	}
+	for op := e.peek(); op == "*" || op == "/"; op = e.peek() {
+		e.i++
+		rhs, err := e.unary()
+		if err != nil {
+			return 0, err
+		}
+		if op == "*" {
+			v *= rhs
+		} else if rhs == 0 {
+			return 0, fmt.Errorf("division by zero in expression")
+		} else {
+			v /= rhs
+		}
+	}
+	return v, nil
+}
+
+func (e *exprParser) unary() (float64, error) {
+	switch e.peek() {
+	case "-":
+		e.i++
+		v, err := e.unary()
+		return -v, err
+	case "+":
+		e.i++
+		return e.unary()
+	}
+	return e.operand()
+}
+
+func (e *exprParser) operand() (float64, error) {
+	tok := e.peek()
+	e.i++
+	switch {
+	case tok == "":
+		return 0, fmt.Errorf("unexpected end of expression")
+	case tok == "(":
+		v, err := e.sum()
+		if err != nil {
+			return 0, err
+		}
+		if e.peek() != ")" {
+			return 0, fmt.Errorf("missing %q in expression", ")")
+		}
+		e.i++
+		return v, nil
+	case tok == ")" || tok == "*" || tok == "/":
+		return 0, fmt.Errorf("unexpected %q in expression", tok)
+	}
+
+	if isNumericLiteral(tok) {
+		return strconv.ParseFloat(tok, 64)
+	}
+	return e.lookup(tok)
+}
+
+// isNumericLiteral reports whether tok is written as a decimal number, e.g. 5, 0.25 or .5,
+// so names such as inf and nan are looked up as constants rather than parsed as numbers
+func isNumericLiteral(tok string) bool {
+	digits, dots := 0, 0
+	for _, ch := range tok {
+		switch {
+		case ch >= '0' && ch <= '9':
+			digits++
+		case ch == '.':
+			dots++
+		default:
+			return false
+		}
+	}
+	return digits > 0 && dots <= 1
+}
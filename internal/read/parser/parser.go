@@ -0,0 +1,213 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/lexer"
+)
+
+// blockTags are identifiers which may directly precede a block value, e.g. `color = rgb { 255 0 0 }`
+var blockTags = map[string]bool{
+	"rgb":    true,
+	"hsv":    true,
+	"hsv360": true,
+}
+
+// Parser builds a syntax tree from the tokens of a single file
+type Parser struct {
+	name     string
+	encoding files.Encoding
+	lex      *lexer.Lexer
+	peeked   *lexer.Token
+	err      error
+}
+
+func New(name string, rdr *files.Reader) *Parser {
+	return &Parser{name: name, encoding: rdr.Encoding(), lex: lexer.New(rdr)}
+}
+
+// readerOptions are used for every file parsed, so positions match a text editor whatever the encoding
+func readerOptions() []files.Option {
+	return []files.Option{files.DetectEncoding(), files.SkipBOM(), files.NormalizeNewlines()}
+}
+
+// ParseFile opens, parses, and closes the DataFile
+func ParseFile(df files.DataFile) (*File, error) {
+	return parseReader(df.NewReader(readerOptions()...))
+}
+
+// ParseSource opens, parses, and closes the Source
+func ParseSource(src files.Source) (*File, error) {
+	return parseReader(src.NewReader(readerOptions()...))
+}
+
+// ParseFS opens, parses, and closes the file at path in fsys
+func ParseFS(fsys fs.FS, path string) (*File, error) {
+	return parseReader(files.OpenFS(fsys, path, readerOptions()...))
+}
+
+// ParseReader parses everything read from src, e.g. content which is never written to disk.
+// The name is used for the File and in error messages.
+func ParseReader(name string, src io.Reader) (*File, error) {
+	return parseReader(files.NewReader(name, src, readerOptions()...))
+}
+
+func parseReader(rdr *files.Reader, err error) (*File, error) {
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rdr.Close() }()
+
+	return New(rdr.Name(), rdr).Parse()
+}
+
+// Parse reads the whole file into a syntax tree, substituting the file's @ constants
+func (p *Parser) Parse() (*File, error) {
+	root, err := p.parseItems(nil)
+	if err != nil {
+		return nil, err
+	}
+	constants, err := p.resolveConstants(root)
+	if err != nil {
+		return nil, err
+	}
+	if len(root.Items) > 0 {
+		root.Open = root.Items[0].Start()
+		root.Close = root.Items[len(root.Items)-1].End()
+	}
+	return &File{Name: p.name, Encoding: p.encoding, Constants: constants, Block: root}, nil
+}
+
+// parseItems reads items until the closing brace of the block opened by open,
+// or until the end of the file if open is nil
+func (p *Parser) parseItems(open *lexer.Token) (*Block, error) {
+	blk := &Block{}
+	if open != nil {
+		blk.Open = open.Start
+	}
+
+	for {
+		tok, err := p.next()
+		if err == io.EOF {
+			if open != nil {
+				return nil, p.errorf(open.Start, "unexpected end of file, block is never closed")
+			}
+			return blk, nil
+		} else if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case tok.Type == lexer.CloseBrace:
+			if open == nil {
+				return nil, p.errorf(tok.Start, "unexpected %q", tok.Text)
+			}
+			blk.Close = tok.Start
+			return blk, nil
+		case tok.Type == lexer.OpenBrace:
+			item, err := p.parseItems(&tok)
+			if err != nil {
+				return nil, err
+			}
+			blk.Items = append(blk.Items, item)
+		case tok.Type.IsScalar():
+			item, err := p.parseScalarOrField(tok)
+			if err != nil {
+				return nil, err
+			}
+			blk.Items = append(blk.Items, item)
+		default:
+			return nil, p.errorf(tok.Start, "unexpected %q", tok.Text)
+		}
+	}
+}
+
+// parseScalarOrField continues from a scalar token, which is a field key if it is followed by an operator
+func (p *Parser) parseScalarOrField(key lexer.Token) (Node, error) {
+	op, err := p.peek()
+	if err == io.EOF || (err == nil && !op.Type.IsOperator()) {
+		return &Scalar{Token: key}, nil
+	} else if err != nil {
+		return nil, err
+	}
+	_, _ = p.next()
+
+	val, err := p.parseValue(op)
+	if err != nil {
+		return nil, err
+	}
+	return &Field{Key: &Scalar{Token: key}, Op: op, Value: val}, nil
+}
+
+// parseValue reads the value on the right hand side of the operator op
+func (p *Parser) parseValue(op lexer.Token) (Value, error) {
+	tok, err := p.next()
+	if err == io.EOF {
+		return nil, p.errorf(op.Start, "missing value after %q", op.Text)
+	} else if err != nil {
+		return nil, err
+	}
+
+	switch {
+	case tok.Type == lexer.OpenBrace:
+		return p.parseItems(&tok)
+	case tok.Type.IsScalar():
+		next, err := p.peek()
+		if err == nil && next.Type == lexer.OpenBrace && tok.Type == lexer.Identifier && blockTags[tok.Text] {
+			_, _ = p.next()
+			blk, err := p.parseItems(&next)
+			if err != nil {
+				return nil, err
+			}
+			blk.Tag = tok.Text
+			return blk, nil
+		}
+		return &Scalar{Token: tok}, nil
+	default:
+		return nil, p.errorf(tok.Start, "unexpected %q after %q", tok.Text, op.Text)
+	}
+}
+
+// next returns the next token which is not a comment
+func (p *Parser) next() (lexer.Token, error) {
+	if p.peeked != nil {
+		tok := *p.peeked
+		p.peeked = nil
+		return tok, p.err
+	}
+	for {
+		tok, err := p.lex.Next()
+		var lexErr *lexer.Error
+		if errors.As(err, &lexErr) {
+			return tok, p.errorf(lexErr.Pos, "%s", lexErr.Msg)
+		} else if err != nil || tok.Type != lexer.Comment {
+			return tok, err
+		}
+	}
+}
+
+func (p *Parser) peek() (lexer.Token, error) {
+	if p.peeked == nil {
+		tok, err := p.next()
+		p.peeked, p.err = &tok, err
+	}
+	return *p.peeked, p.err
+}
+
+// Error is a syntax error at a position in a file
+type Error struct {
+	File string
+	Pos  files.Position
+	Msg  string
+}
+
+func (p *Parser) errorf(pos files.Position, format string, args ...any) *Error {
+	return &Error{File: p.name, Pos: pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s:%d:%d: %s", e.File, e.Pos.Line(), e.Pos.Col(), e.Msg)
+}
@@ -0,0 +1,352 @@
+package parser
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"vic3-data-reader/internal/read/files"
+	"vic3-data-reader/internal/read/lexer"
+)
+
+const (
+	DoesNotExist  files.DataFile = "testdata/DOES-NOT-EXIST.txt"
+	Goods         files.DataFile = "testdata/goods.txt"
+	Blocks        files.DataFile = "testdata/blocks.txt"
+	Unclosed      files.DataFile = "testdata/unclosed.txt"
+	StrayClose    files.DataFile = "testdata/stray-close.txt"
+	MissingValue  files.DataFile = "testdata/missing-value.txt"
+	Unterminated  files.DataFile = "testdata/unterminated.txt"
+	Windows1252   files.DataFile = "testdata/windows-1252.txt"
+	Constants     files.DataFile = "testdata/constants.txt"
+	Undefined     files.DataFile = "testdata/undefined-constant.txt"
+	BadExpression files.DataFile = "testdata/bad-expression.txt"
+	UsedEarly     files.DataFile = "testdata/use-before-definition.txt"
+	ExpectedGoods                = 3
+)
+
+func parse(t *testing.T, df files.DataFile) *File {
+	file, err := ParseFile(df)
+	if err != nil {
+		t.Fatalf("ParseFile returned unexpected error: %v", err)
+	}
+	return file
+}
+
+// block returns the block value of the last field with the given key
+func block(t *testing.T, b *Block, key string) *Block {
+	f, ok := b.Get(key)
+	if !ok {
+		t.Fatalf("missing field %q", key)
+	}
+	blk, ok := f.Value.(*Block)
+	if !ok {
+		t.Fatalf("field %q is not a block", key)
+	}
+	return blk
+}
+
+// scalar returns the text of the scalar value of the last field with the given key
+func scalar(t *testing.T, b *Block, key string) string {
+	f, ok := b.Get(key)
+	if !ok {
+		t.Fatalf("missing field %q", key)
+	}
+	s, ok := f.Value.(*Scalar)
+	if !ok {
+		t.Fatalf("field %q is not a scalar", key)
+	}
+	return s.Text()
+}
+
+func TestParseFile_errOnMissingFile(t *testing.T) {
+	_, err := ParseFile(DoesNotExist)
+	if err == nil {
+		t.Errorf("ParseFile did not return an error for missing file")
+	}
+}
+
+func TestParseFile_goods(t *testing.T) {
+	file := parse(t, Goods)
+
+	keys := file.Keys()
+	if len(keys) != ExpectedGoods {
+		t.Fatalf("expected %d goods, actual: %d", ExpectedGoods, len(keys))
+	}
+	if keys[0] != "ammunition" {
+		t.Errorf("expected: %s, actual: %s", "ammunition", keys[0])
+	}
+
+	ammunition := block(t, file.Block, "ammunition")
+	if cost := scalar(t, ammunition, "cost"); cost != "50" {
+		t.Errorf("expected: %s, actual: %s", "50", cost)
+	}
+	if category := scalar(t, ammunition, "category"); category != "military" {
+		t.Errorf("expected: %s, actual: %s", "military", category)
+	}
+	if texture := scalar(t, ammunition, "texture"); texture != "gfx/interface/icons/goods_icons/ammunition.dds" {
+		t.Errorf("unexpected texture: %s", texture)
+	}
+}
+
+func TestParseFile_positionsArePreserved(t *testing.T) {
+	file := parse(t, Goods)
+	ammunition, _ := file.Get("ammunition")
+	smallArms, _ := file.Get("small_arms")
+
+	start, end := ammunition.Start(), ammunition.End()
+	if start.Col() != 1 {
+		t.Errorf("expected start col: %d, actual: %d", 1, start.Col())
+	}
+	if end.Line()-start.Line() != 7 {
+		t.Errorf("expected ammunition to span 8 lines, actual: %d", end.Line()-start.Line()+1)
+	}
+	if smallArms.Start().Line() != end.Line()+2 {
+		t.Errorf("expected small_arms to start 2 lines after ammunition ends; actual lines %d and %d", end.Line(), smallArms.Start().Line())
+	}
+}
+
+func TestParseFile_repeatedKeys(t *testing.T) {
+	file := parse(t, Blocks)
+	building := block(t, file.Block, "building_food_industry")
+
+	pmgs := building.GetAll("production_method_groups")
+	if len(pmgs) != 2 {
+		t.Fatalf("expected 2 production_method_groups, actual: %d", len(pmgs))
+	}
+	first, ok := pmgs[0].Value.(*Block)
+	if !ok || !first.IsArray() || len(first.Scalars()) != 2 {
+		t.Errorf("expected first production_method_groups to be an array of 2 scalars")
+	}
+	last := block(t, building, "production_method_groups")
+	if last != pmgs[1].Value {
+		t.Errorf("Get should return the last repeated field")
+	}
+}
+
+func TestParseFile_emptyBlock(t *testing.T) {
+	file := parse(t, Blocks)
+	building := block(t, file.Block, "building_food_industry")
+
+	techs := block(t, building, "unlocking_technologies")
+	if !techs.IsEmpty() {
+		t.Errorf("expected empty block, actual has %d items", len(techs.Items))
+	}
+	if techs.IsArray() {
+		t.Errorf("empty block should not be an array")
+	}
+}
+
+func TestParseFile_comparisonOperators(t *testing.T) {
+	file := parse(t, Blocks)
+	possible := block(t, block(t, file.Block, "building_food_industry"), "possible")
+
+	expected := map[string]lexer.TokenType{
+		"owner":          lexer.Assign,
+		"age":            lexer.GreaterEqual,
+		"is_subsistence": lexer.QuestionEqual,
+		"NOT":            lexer.Assign,
+	}
+	for key, typ := range expected {
+		f, ok := possible.Get(key)
+		if !ok {
+			t.Errorf("missing field %q", key)
+		} else if f.Op.Type != typ {
+			t.Errorf("%s: expected: %s, actual: %s", key, typ, f.Op.Type)
+		}
+	}
+
+	age, _ := possible.Get("age")
+	if age.IsAssignment() {
+		t.Errorf("comparison should not be an assignment")
+	}
+}
+
+func TestParseFile_anonymousBlocks(t *testing.T) {
+	file := parse(t, Blocks)
+	anonymous := block(t, file.Block, "anonymous")
+
+	blocks := anonymous.Blocks()
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 anonymous blocks, actual: %d", len(blocks))
+	}
+	if len(blocks[1].Scalars()) != 2 {
+		t.Errorf("expected second anonymous block to have 2 scalars, actual: %d", len(blocks[1].Scalars()))
+	}
+	if len(anonymous.Fields()) != 0 {
+		t.Errorf("expected no fields, actual: %d", len(anonymous.Fields()))
+	}
+}
+
+func TestParseFile_taggedBlock(t *testing.T) {
+	file := parse(t, Blocks)
+	color := block(t, file.Block, "color")
+	if color.Tag != "rgb" {
+		t.Errorf("expected: %s, actual: %s", "rgb", color.Tag)
+	}
+	if len(color.Scalars()) != 3 {
+		t.Errorf("expected 3 scalars, actual: %d", len(color.Scalars()))
+	}
+}
+
+func TestParseFile_syntaxErrors(t *testing.T) {
+	for _, df := range []files.DataFile{Unclosed, StrayClose, MissingValue, Unterminated} {
+		_, err := ParseFile(df)
+		var parseErr *Error
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected a syntax error, actual: %v", df, err)
+		} else if parseErr.File != string(df) {
+			t.Errorf("%s: error has wrong file name %s", df, parseErr.File)
+		}
+	}
+}
+
+func TestParseFile_encoding(t *testing.T) {
+	file := parse(t, Windows1252)
+	if file.Encoding != files.Windows1252 {
+		t.Errorf("expected: %s, actual: %s", files.Windows1252, file.Encoding)
+	}
+	if name := scalar(t, file.Block, "name"); name != "Café €" {
+		t.Errorf("expected: %s, actual: %s", "Café €", name)
+	}
+
+	if goods := parse(t, Goods); goods.Encoding != files.UTF8 {
+		t.Errorf("expected: %s, actual: %s", files.UTF8, goods.Encoding)
+	}
+}
+
+func TestParseReader(t *testing.T) {
+	file, err := ParseReader("inline", strings.NewReader("ammunition = {\n\tcost = 50\n}\n"))
+	if err != nil {
+		t.Fatalf("ParseReader returned unexpected error: %v", err)
+	}
+	if file.Name != "inline" {
+		t.Errorf("expected: %s, actual: %s", "inline", file.Name)
+	}
+	if cost := scalar(t, block(t, file.Block, "ammunition"), "cost"); cost != "50" {
+		t.Errorf("expected: %s, actual: %s", "50", cost)
+	}
+
+	_, err = ParseReader("inline", strings.NewReader("a = {"))
+	var parseErr *Error
+	if !errors.As(err, &parseErr) || parseErr.File != "inline" {
+		t.Errorf("expected a syntax error in %s, actual: %v", "inline", err)
+	}
+}
+
+func TestParseFS(t *testing.T) {
+	fsys := fstest.MapFS{
+		"common/goods/00_goods.txt": &fstest.MapFile{Data: []byte("ammunition = { cost = 50 }\nsmall_arms = { cost = 60 }\n")},
+	}
+	file, err := ParseFS(fsys, "common/goods/00_goods.txt")
+	if err != nil {
+		t.Fatalf("ParseFS returned unexpected error: %v", err)
+	}
+	if file.Name != "common/goods/00_goods.txt" {
+		t.Errorf("expected: %s, actual: %s", "common/goods/00_goods.txt", file.Name)
+	}
+	if len(file.Keys()) != 2 {
+		t.Errorf("expected 2 keys, actual: %d", len(file.Keys()))
+	}
+
+	if _, err := ParseFS(fsys, "DOES-NOT-EXIST.txt"); err == nil {
+		t.Errorf("ParseFS did not return an error for missing file")
+	}
+}
+
+func TestParseFile_constants(t *testing.T) {
+	file := parse(t, Constants)
+
+	if len(file.Constants) != 4 {
+		t.Fatalf("expected 4 constants, actual: %d", len(file.Constants))
+	}
+	if keys := file.Keys(); len(keys) != 2 || keys[0] != "ammunition" {
+		t.Errorf("constants should not be top level items; actual keys: %v", keys)
+	}
+	if double, ok := file.Constant("double_cost"); !ok || double.Text() != "100" {
+		t.Errorf("expected @double_cost = 100, actual: %v", double)
+	}
+
+	ammunition := block(t, file.Block, "ammunition")
+	expected := map[string]string{
+		"texture":          "gfx/interface/icons/goods_icons/ammunition.dds",
+		"cost":             "50",
+		"prestige_factor":  "7",
+		"traded_quantity":  "9",
+		"obsession_chance": "0.75",
+	}
+	for key, text := range expected {
+		if actual := scalar(t, ammunition, key); actual != text {
+			t.Errorf("%s: expected: %s, actual: %s", key, text, actual)
+		}
+	}
+
+	texture, _ := ammunition.Get("texture")
+	if s := texture.Value.(*Scalar); !s.Quoted() || s.Original == nil || s.Original.Text != "@texture" {
+		t.Errorf("expected substituted string to keep its type and original reference, actual: %+v", s)
+	}
+	cost, _ := ammunition.Get("cost")
+	if s := cost.Value.(*Scalar); !s.IsNumber() || s.Start().Line() != 8 || s.Start().Col() != 9 {
+		t.Errorf("expected substituted number at 8:9, actual: %s", s.Token)
+	}
+
+	levels := block(t, ammunition, "levels").Scalars()
+	if len(levels) != 3 || levels[0].Text() != "50" || levels[1].Text() != "100" || levels[2].Original != nil {
+		t.Errorf("unexpected levels: %v", levels)
+	}
+
+	smallArms := block(t, file.Block, "small_arms")
+	if multiplier := scalar(t, smallArms, "convoy_cost_multiplier"); multiplier != "12.25" {
+		t.Errorf("expected: %s, actual: %s", "12.25", multiplier)
+	}
+}
+
+func TestParseFile_constantErrors(t *testing.T) {
+	tests := map[files.DataFile][2]int{
+		Undefined:     {2, 9},
+		BadExpression: {3, 9},
+		UsedEarly:     {2, 9},
+	}
+	for df, pos := range tests {
+		_, err := ParseFile(df)
+		var parseErr *Error
+		if !errors.As(err, &parseErr) {
+			t.Errorf("%s: expected a syntax error, actual: %v", df, err)
+		} else if parseErr.Pos.Line() != pos[0] || parseErr.Pos.Col() != pos[1] {
+			t.Errorf("%s: expected error at %d:%d, actual: %v", df, pos[0], pos[1], err)
+		}
+	}
+}
+
+func TestEvalExpression(t *testing.T) {
+	lookup := func(name string) (float64, error) {
+		if name == "x" || name == "@x" {
+			return 4, nil
+		}
+		return 0, fmt.Errorf("undefined %s", name)
+	}
+	tests := map[string]float64{
+		"1 + 2 * 3":     7,
+		"(1 + 2) * 3":   9,
+		"10 - 4 - 3":    3,
+		"8 / 2 / 2":     2,
+		"-x + 1":        -3,
+		"@x * -(2 - 3)": 4,
+		".5 * x":        2,
+	}
+	for expr, expected := range tests {
+		actual, err := evalExpression(expr, lookup)
+		if err != nil {
+			t.Errorf("%q: unexpected error: %v", expr, err)
+		} else if actual != expected {
+			t.Errorf("%q: expected: %v, actual: %v", expr, expected, actual)
+		}
+	}
+
+	for _, expr := range []string{"", "1 +", "(1 + 2", "1 2", "y * 2", "1 % 2", "4 / (x - 4)", "inf * 2", "nan", "-Inf"} {
+		if _, err := evalExpression(expr, lookup); err == nil {
+			t.Errorf("%q: expected an error", expr)
+		}
+	}
+}